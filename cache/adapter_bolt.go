@@ -0,0 +1,460 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltBucket 缓存数据所在的 bucket
+var boltBucket = []byte("cache")
+
+// BoltCacheConfig 嵌入式 bbolt 缓存配置
+type BoltCacheConfig struct {
+	// Path 数据库文件路径
+	Path string `yaml:"path"`
+	// SyncWrites 是否在每次写事务提交后同步到磁盘，关闭可提高写入性能但进程崩溃时可能丢失最近的写入
+	SyncWrites bool `yaml:"sync_writes"`
+	// OpenTimeout 等待数据库文件锁的超时时间，默认1秒
+	OpenTimeout time.Duration `yaml:"open_timeout"`
+}
+
+// BadgerConfig 缓存类型 badger 的配置，badger 是 bolt 的别名，同样由 bbolt 实现
+type BadgerConfig = BoltCacheConfig
+
+// BoltCache 基于 bbolt 的嵌入式键值存储实现，数据在进程重启后保留
+// 过期时间与值一同保存，读取时检查，并由清理协程定期删除过期项
+type BoltCache struct {
+	db              *bolt.DB
+	cleanupInterval time.Duration
+	defaultTTL      time.Duration
+	slidingTTL      bool
+	stopCleanup     chan struct{}
+	closeOnce       sync.Once
+	stats           *StatsCollector
+	keyLocks        keyMutex
+}
+
+// boltItem 缓存项
+type boltItem struct {
+	Value      interface{} `json:"value"`
+	Expiration *time.Time  `json:"expiration,omitempty"`
+}
+
+// expired 判断缓存项是否已过期
+func (item *boltItem) expired(now time.Time) bool {
+	return item.Expiration != nil && now.After(*item.Expiration)
+}
+
+// NewBoltCache 创建 bbolt 缓存实例
+func NewBoltCache(config *BaseConfig, cacheConfig *BoltCacheConfig) (*BoltCache, error) {
+	if cacheConfig.Path == "" {
+		return nil, fmt.Errorf("bolt cache path is required")
+	}
+
+	timeout := cacheConfig.OpenTimeout
+	if timeout <= 0 {
+		timeout = time.Second
+	}
+	db, err := bolt.Open(cacheConfig.Path, 0644, &bolt.Options{
+		Timeout: timeout,
+		NoSync:  !cacheConfig.SyncWrites,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt database: %v", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create bolt bucket: %v", err)
+	}
+
+	cache := &BoltCache{
+		db:              db,
+		cleanupInterval: time.Duration(config.CleanupInterval) * time.Second,
+		defaultTTL:      config.DefaultExpiration,
+		slidingTTL:      config.SlidingTTL,
+		stopCleanup:     make(chan struct{}),
+		stats:           NewStatsCollector(),
+	}
+
+	// 启动清理协程
+	if cache.cleanupInterval > 0 {
+		go cache.startCleanup()
+	}
+
+	return cache, nil
+}
+
+// Set 设置缓存
+func (c *BoltCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return c.putItem(tx.Bucket(boltBucket), key, value, ttl)
+	})
+}
+
+// putItem 写入缓存项，ttl 不大于0时使用默认过期时间，默认过期时间也未设置时永不过期
+func (c *BoltCache) putItem(bucket *bolt.Bucket, key string, value interface{}, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	item := &boltItem{Value: value}
+	if ttl > 0 {
+		expiration := time.Now().Add(ttl)
+		item.Expiration = &expiration
+	}
+	return writeBoltItem(bucket, key, item)
+}
+
+// Get 获取缓存
+func (c *BoltCache) Get(ctx context.Context, key string, value interface{}) error {
+	var item *boltItem
+	read := func(tx *bolt.Tx) error {
+		var err error
+		item, err = c.readItem(tx.Bucket(boltBucket), key)
+		if err != nil || item == nil {
+			return err
+		}
+		return c.touch(tx.Bucket(boltBucket), key, item)
+	}
+
+	// 滑动过期需要改写缓存项，因此使用写事务
+	var err error
+	if c.slidingTTL && c.defaultTTL > 0 {
+		err = c.db.Update(read)
+	} else {
+		err = c.db.View(read)
+	}
+	if err != nil {
+		return err
+	}
+
+	if item == nil {
+		c.stats.IncrMisses()
+		return ErrNotFound
+	}
+
+	if err := assignValue(value, item.Value); err != nil {
+		return err
+	}
+	c.stats.IncrHits()
+	return nil
+}
+
+// touch 启用滑动过期时，将命中项的过期时间重置为默认过期时间
+// 未设置过期时间的缓存项以及只读事务不受影响
+func (c *BoltCache) touch(bucket *bolt.Bucket, key string, item *boltItem) error {
+	if !bucket.Tx().Writable() || item.Expiration == nil {
+		return nil
+	}
+	expiration := time.Now().Add(c.defaultTTL)
+	item.Expiration = &expiration
+	return writeBoltItem(bucket, key, item)
+}
+
+// Delete 删除缓存
+func (c *BoltCache) Delete(ctx context.Context, key string) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		return deleteBoltItem(tx.Bucket(boltBucket), key)
+	})
+}
+
+// Has 检查缓存是否存在
+func (c *BoltCache) Has(ctx context.Context, key string) (bool, error) {
+	var exists bool
+	err := c.db.View(func(tx *bolt.Tx) error {
+		item, err := c.readItem(tx.Bucket(boltBucket), key)
+		exists = item != nil
+		return err
+	})
+	return exists, err
+}
+
+// Clear 清空所有缓存
+func (c *BoltCache) Clear(ctx context.Context) error {
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(boltBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(boltBucket)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear bolt bucket: %v", err)
+	}
+
+	c.stats.Reset()
+	return nil
+}
+
+// GetStats 获取缓存统计信息
+// 键数量取自数据库，包含尚未被清理的过期项
+func (c *BoltCache) GetStats(ctx context.Context) (*Stats, error) {
+	stats := c.stats.GetStats()
+	err := c.db.View(func(tx *bolt.Tx) error {
+		stats.KeyCount = int64(tx.Bucket(boltBucket).Stats().KeyN)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// HealthCheck 执行健康检查
+func (c *BoltCache) HealthCheck(ctx context.Context) (*Health, error) {
+	stats, err := c.GetStats(ctx)
+	if err != nil {
+		return &Health{
+			Status:    "unhealthy",
+			Details:   map[string]interface{}{"error": err.Error()},
+			Timestamp: time.Now(),
+		}, nil
+	}
+
+	return &Health{
+		Status: "healthy",
+		Details: map[string]interface{}{
+			"path":          c.db.Path(),
+			"key_count":     stats.KeyCount,
+			"hits":          stats.Hits,
+			"misses":        stats.Misses,
+			"expired_count": stats.ExpiredCount,
+		},
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// MSet 批量设置缓存，所有缓存项在同一个写事务中写入
+func (c *BoltCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		for key, value := range items {
+			if err := c.putItem(bucket, key, value, ttl); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MGet 批量获取缓存，不存在或已过期的键不会出现在结果中
+func (c *BoltCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	err := c.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		for _, key := range keys {
+			item, err := c.readItem(bucket, key)
+			if err != nil {
+				return err
+			}
+			if item == nil {
+				c.stats.IncrMisses()
+				continue
+			}
+			result[key] = item.Value
+			c.stats.IncrHits()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MDelete 批量删除缓存
+func (c *BoltCache) MDelete(ctx context.Context, keys []string) error {
+	return c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		for _, key := range keys {
+			if err := deleteBoltItem(bucket, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+func (c *BoltCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	var value int64
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		value, err = c.incrItem(tx.Bucket(boltBucket), key, delta, ttl)
+		return err
+	})
+	return value, err
+}
+
+// incrItem 为整数缓存项增加 delta
+func (c *BoltCache) incrItem(bucket *bolt.Bucket, key string, delta int64, ttl time.Duration) (int64, error) {
+	item, err := c.readItem(bucket, key)
+	if err != nil {
+		return 0, err
+	}
+
+	value := delta
+	if item != nil {
+		current, ok := toInt64(item.Value)
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrNotInteger, key)
+		}
+		value = current + delta
+		item.Value = value
+		return value, writeBoltItem(bucket, key, item)
+	}
+
+	// 键不存在或已过期，以 delta 创建
+	return value, c.putItem(bucket, key, value, ttl)
+}
+
+// Txn 在同一个 bbolt 写事务中执行事务，任一操作失败时全部回滚
+func (c *BoltCache) Txn(ctx context.Context, fn func(tx Txn) error) error {
+	ops, err := collectTxn(fn)
+	if err != nil {
+		return err
+	}
+
+	return c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		err := validateTxn(ops, func(key string) (interface{}, bool, error) {
+			item, err := c.readItem(bucket, key)
+			if err != nil || item == nil {
+				return nil, false, err
+			}
+			return item.Value, true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, op := range ops {
+			switch op.typ {
+			case txnSet:
+				err = c.putItem(bucket, op.key, op.value, op.ttl)
+			case txnDelete:
+				err = deleteBoltItem(bucket, op.key)
+			case txnIncrBy:
+				_, err = c.incrItem(bucket, op.key, op.delta, op.ttl)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DefaultExpiration 返回默认过期时间
+func (c *BoltCache) DefaultExpiration() time.Duration {
+	return c.defaultTTL
+}
+
+// ResetStats 重置统计信息
+func (c *BoltCache) ResetStats(ctx context.Context) error {
+	c.stats.Reset()
+	return nil
+}
+
+// Close 停止清理协程并关闭数据库
+func (c *BoltCache) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.stopCleanup)
+		err = c.db.Close()
+	})
+	return err
+}
+
+// readItem 读取未过期的缓存项，键不存在或已过期时返回 nil
+func (c *BoltCache) readItem(bucket *bolt.Bucket, key string) (*boltItem, error) {
+	data := bucket.Get([]byte(key))
+	if data == nil {
+		return nil, nil
+	}
+
+	var item boltItem
+	if err := json.Unmarshal(data, &item); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cache item: %v", err)
+	}
+	if item.expired(time.Now()) {
+		c.stats.IncrExpiredCount()
+		return nil, nil
+	}
+	return &item, nil
+}
+
+// writeBoltItem 序列化并写入缓存项
+func writeBoltItem(bucket *bolt.Bucket, key string, item *boltItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMarshal, err)
+	}
+	if err := bucket.Put([]byte(key), data); err != nil {
+		return fmt.Errorf("failed to write cache item: %v", err)
+	}
+	return nil
+}
+
+// deleteBoltItem 删除缓存项，键不存在时不返回错误
+func deleteBoltItem(bucket *bolt.Bucket, key string) error {
+	if err := bucket.Delete([]byte(key)); err != nil {
+		return fmt.Errorf("failed to delete cache item: %v", err)
+	}
+	return nil
+}
+
+// startCleanup 启动清理协程
+func (c *BoltCache) startCleanup() {
+	ticker := time.NewTicker(c.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.deleteExpired()
+		case <-c.stopCleanup:
+			return
+		}
+	}
+}
+
+// deleteExpired 删除过期的缓存项
+func (c *BoltCache) deleteExpired() {
+	now := time.Now()
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		var expired [][]byte
+		err := bucket.ForEach(func(k, v []byte) error {
+			var item boltItem
+			if err := json.Unmarshal(v, &item); err != nil {
+				return nil
+			}
+			if item.expired(now) {
+				expired = append(expired, bytes.Clone(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+			c.stats.IncrExpiredCount()
+		}
+		return nil
+	})
+}
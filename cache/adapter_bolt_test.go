@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestBoltCache 在临时目录中创建 bbolt 缓存
+func newTestBoltCache(t *testing.T, path string) *BoltCache {
+	t.Helper()
+	cache, err := NewBoltCache(&BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}, &BoltCacheConfig{Path: path})
+	if err != nil {
+		t.Fatalf("NewBoltCache failed: %v", err)
+	}
+	return cache
+}
+
+func TestBoltCache(t *testing.T) {
+	cache := newTestBoltCache(t, filepath.Join(t.TempDir(), "cache.db"))
+	defer cache.Close()
+
+	// 测试 Set 和 Get
+	ctx := context.Background()
+	key := "test_key"
+	value := "test_value"
+	if err := cache.Set(ctx, key, value, time.Minute); err != nil {
+		t.Errorf("Set failed: %v", err)
+	}
+
+	var result string
+	if err := cache.Get(ctx, key, &result); err != nil {
+		t.Errorf("Get failed: %v", err)
+	}
+	if result != value {
+		t.Errorf("Expected %v, got %v", value, result)
+	}
+
+	// 测试 Has
+	exists, err := cache.Has(ctx, key)
+	if err != nil {
+		t.Errorf("Has failed: %v", err)
+	}
+	if !exists {
+		t.Error("Expected key to exist")
+	}
+
+	// 测试 Delete
+	if err = cache.Delete(ctx, key); err != nil {
+		t.Errorf("Delete failed: %v", err)
+	}
+	if err = cache.Get(ctx, key, &result); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+
+	// 测试过期
+	if err = cache.Set(ctx, key, value, time.Millisecond); err != nil {
+		t.Errorf("Set failed: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if err = cache.Get(ctx, key, &result); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for expired key, got %v", err)
+	}
+
+	// 过期项由清理协程删除
+	cache.deleteExpired()
+	stats, err := cache.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.KeyCount != 0 {
+		t.Errorf("Expected 0 keys after cleanup, got %d", stats.KeyCount)
+	}
+}
+
+func TestBoltCachePersistence(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cache.db")
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	cache := newTestBoltCache(t, path)
+	if err := cache.Set(ctx, "user", user{Name: "alice", Age: 30}, time.Hour); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// 重新打开后数据仍然存在
+	cache = newTestBoltCache(t, path)
+	defer cache.Close()
+
+	var got user
+	if err := cache.Get(ctx, "user", &got); err != nil {
+		t.Fatalf("Get after reopen failed: %v", err)
+	}
+	if got.Name != "alice" || got.Age != 30 {
+		t.Errorf("Expected alice/30, got %+v", got)
+	}
+}
+
+func TestBoltCacheBatchAndIncr(t *testing.T) {
+	ctx := context.Background()
+	cache := newTestBoltCache(t, filepath.Join(t.TempDir(), "cache.db"))
+	defer cache.Close()
+
+	if err := cache.MSet(ctx, map[string]interface{}{"a": 1, "b": 2}, time.Minute); err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+	values, err := cache.MGet(ctx, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("MGet failed: %v", err)
+	}
+	if len(values) != 2 {
+		t.Errorf("Expected 2 values, got %d", len(values))
+	}
+
+	n, err := cache.IncrBy(ctx, "a", 5, 0)
+	if err != nil {
+		t.Fatalf("IncrBy failed: %v", err)
+	}
+	if n != 6 {
+		t.Errorf("Expected 6, got %d", n)
+	}
+
+	if err := cache.Set(ctx, "s", "text", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := cache.IncrBy(ctx, "s", 1, 0); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue, got %v", err)
+	}
+
+	// 事务中任一操作失败时不写入任何数据
+	err = cache.Txn(ctx, func(tx Txn) error {
+		tx.Set("c", 3, time.Minute)
+		tx.IncrBy("s", 1, 0)
+		return nil
+	})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrInvalidValue from Txn, got %v", err)
+	}
+	if exists, _ := cache.Has(ctx, "c"); exists {
+		t.Error("Expected failed transaction to write nothing")
+	}
+
+	if err := cache.MDelete(ctx, []string{"a", "b"}); err != nil {
+		t.Fatalf("MDelete failed: %v", err)
+	}
+	if err := cache.Clear(ctx); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	stats, _ := cache.GetStats(ctx)
+	if stats.KeyCount != 0 {
+		t.Errorf("Expected 0 keys after Clear, got %d", stats.KeyCount)
+	}
+}
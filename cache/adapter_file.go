@@ -2,7 +2,6 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,11 +21,15 @@ type FileCache struct {
 	mutex           sync.RWMutex
 	directory       string
 	cleanupInterval time.Duration
+	defaultTTL      time.Duration
+	slidingTTL      bool
 	stopCleanup     chan bool
 	stats           *StatsCollector
 	tags            map[string][]string
 	listeners       []EventListener
 	data            map[string]*fileItem
+	serializer      Serializer
+	keyLocks        keyMutex
 }
 
 // item 缓存项
@@ -41,11 +44,14 @@ func NewFileCache(config *BaseConfig, cacheConfig *FileCacheConfig) *FileCache {
 	cache := &FileCache{
 		directory:       cacheConfig.Directory,
 		cleanupInterval: time.Duration(config.CleanupInterval) * time.Second,
+		defaultTTL:      config.DefaultExpiration,
+		slidingTTL:      config.SlidingTTL,
 		stopCleanup:     make(chan bool),
 		stats:           NewStatsCollector(),
 		tags:            make(map[string][]string),
 		listeners:       make([]EventListener, 0),
 		data:            make(map[string]*fileItem),
+		serializer:      serializerOrDefault(config.Serializer),
 	}
 
 	// 确保目录存在
@@ -64,15 +70,20 @@ func (c *FileCache) Set(ctx context.Context, key string, value interface{}, ttl
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	return c.setItem(key, value, ttl)
+}
+
+// setItem 写入缓存文件，调用方需持有写锁
+func (c *FileCache) setItem(key string, value interface{}, ttl time.Duration) error {
 	expiration := time.Now().Add(ttl)
 	item := &fileItem{
 		Value:      value,
 		Expiration: &expiration,
 	}
 
-	data, err := json.Marshal(item)
+	data, err := c.serializer.Marshal(item)
 	if err != nil {
-		return fmt.Errorf("failed to marshal cache item: %v", err)
+		return fmt.Errorf("%w: %v", ErrMarshal, err)
 	}
 
 	filePath := filepath.Join(c.directory, key)
@@ -88,8 +99,14 @@ func (c *FileCache) Set(ctx context.Context, key string, value interface{}, ttl
 
 // Get 获取缓存
 func (c *FileCache) Get(ctx context.Context, key string, value interface{}) error {
-	c.mutex.RLock()
-	defer c.mutex.RUnlock()
+	// 滑动过期需要改写缓存文件，因此使用写锁
+	if c.slidingTTL {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	} else {
+		c.mutex.RLock()
+		defer c.mutex.RUnlock()
+	}
 
 	filePath := filepath.Join(c.directory, key)
 	data, err := os.ReadFile(filePath)
@@ -102,7 +119,7 @@ func (c *FileCache) Get(ctx context.Context, key string, value interface{}) erro
 	}
 
 	var item fileItem
-	if err := json.Unmarshal(data, &item); err != nil {
+	if err := c.serializer.Unmarshal(data, &item); err != nil {
 		return fmt.Errorf("failed to unmarshal cache item: %v", err)
 	}
 
@@ -129,17 +146,50 @@ func (c *FileCache) Get(ctx context.Context, key string, value interface{}) erro
 	}
 
 	valueElem.Set(cachedValue)
+	if err := c.touch(key, &item); err != nil {
+		return err
+	}
 	c.stats.IncrHits()
 	c.notifyListeners(EventTypeGet, key)
 
 	return nil
 }
 
+// touch 启用滑动过期时，将命中项的过期时间重置为默认过期时间并写回文件
+// 未设置过期时间的缓存项不受影响，调用方需持有写锁
+func (c *FileCache) touch(key string, item *fileItem) error {
+	if !c.slidingTTL || c.defaultTTL <= 0 || item.Expiration == nil {
+		return nil
+	}
+
+	expiration := time.Now().Add(c.defaultTTL)
+	item.Expiration = &expiration
+
+	data, err := c.serializer.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMarshal, err)
+	}
+
+	filePath := filepath.Join(c.directory, key)
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cache file: %v", err)
+	}
+	return nil
+}
+
 // Delete 删除缓存
 func (c *FileCache) Delete(ctx context.Context, key string) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	return c.deleteItem(key)
+}
+
+// deleteItem 删除缓存文件，调用方需持有写锁
+func (c *FileCache) deleteItem(key string) error {
+	// 删除文件前读取标签，删除后无法再读取
+	item, readErr := c.readItem(key)
+
 	filePath := filepath.Join(c.directory, key)
 	if err := os.Remove(filePath); err != nil {
 		if os.IsNotExist(err) {
@@ -149,17 +199,8 @@ func (c *FileCache) Delete(ctx context.Context, key string) error {
 	}
 
 	// 删除标签关系
-	if item, err := c.readItem(key); err == nil {
-		for _, tag := range item.Tags {
-			if keys, ok := c.tags[tag]; ok {
-				for i, k := range keys {
-					if k == key {
-						c.tags[tag] = append(keys[:i], keys[i+1:]...)
-						break
-					}
-				}
-			}
-		}
+	if readErr == nil {
+		c.removeTagRefs(key, item.Tags)
 	}
 
 	c.stats.DecrKeyCount()
@@ -221,6 +262,15 @@ func (c *FileCache) GetStats(ctx context.Context) (*Stats, error) {
 
 // HealthCheck 执行健康检查
 func (c *FileCache) HealthCheck(ctx context.Context) (*Health, error) {
+	// 检查缓存目录是否可写
+	if err := c.checkWritable(); err != nil {
+		return &Health{
+			Status:    "unhealthy",
+			Details:   map[string]interface{}{"error": err.Error()},
+			Timestamp: time.Now(),
+		}, nil
+	}
+
 	stats := c.stats.GetStats()
 	return &Health{
 		Status: "healthy",
@@ -247,9 +297,9 @@ func (c *FileCache) MSet(ctx context.Context, items map[string]interface{}, ttl
 			Expiration: &expiration,
 		}
 
-		data, err := json.Marshal(item)
+		data, err := c.serializer.Marshal(item)
 		if err != nil {
-			return fmt.Errorf("failed to marshal cache item: %v", err)
+			return fmt.Errorf("%w: %v", ErrMarshal, err)
 		}
 
 		filePath := filepath.Join(c.directory, key)
@@ -282,7 +332,7 @@ func (c *FileCache) MGet(ctx context.Context, keys []string) (map[string]interfa
 		}
 
 		var item fileItem
-		if err := json.Unmarshal(data, &item); err != nil {
+		if err := c.serializer.Unmarshal(data, &item); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal cache item: %v", err)
 		}
 
@@ -347,9 +397,9 @@ func (c *FileCache) SetWithTags(ctx context.Context, key string, value interface
 		Tags:       tags,
 	}
 
-	data, err := json.Marshal(item)
+	data, err := c.serializer.Marshal(item)
 	if err != nil {
-		return fmt.Errorf("failed to marshal cache item: %v", err)
+		return fmt.Errorf("%w: %v", ErrMarshal, err)
 	}
 
 	filePath := filepath.Join(c.directory, key)
@@ -368,6 +418,64 @@ func (c *FileCache) SetWithTags(ctx context.Context, key string, value interface
 	return nil
 }
 
+// MSetWithTags 批量设置带标签的缓存
+// 所有缓存文件与标签索引在同一次加锁中完成更新，已存在的键会先移除旧的标签关系
+func (c *FileCache) MSetWithTags(ctx context.Context, items map[string]TaggedValue, ttl time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, tv := range items {
+		expiration := time.Now().Add(ttl)
+		item := &fileItem{
+			Value:      tv.Value,
+			Expiration: &expiration,
+			Tags:       tv.Tags,
+		}
+
+		data, err := c.serializer.Marshal(item)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMarshal, err)
+		}
+
+		// 重建标签索引
+		old, err := c.readItem(key)
+		if err == nil {
+			c.removeTagRefs(key, old.Tags)
+		}
+
+		filePath := filepath.Join(c.directory, key)
+		if err := os.WriteFile(filePath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write cache file: %v", err)
+		}
+
+		// 更新标签关系
+		for _, tag := range tv.Tags {
+			c.tags[tag] = append(c.tags[tag], key)
+		}
+
+		if old == nil {
+			c.stats.IncrKeyCount()
+		}
+		c.notifyListeners(EventTypeSet, key)
+	}
+
+	return nil
+}
+
+// removeTagRefs 移除键的标签关系
+func (c *FileCache) removeTagRefs(key string, tags []string) {
+	for _, tag := range tags {
+		if keys, ok := c.tags[tag]; ok {
+			for i, k := range keys {
+				if k == key {
+					c.tags[tag] = append(keys[:i], keys[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+}
+
 // GetByTag 获取指定标签的所有缓存键
 func (c *FileCache) GetByTag(ctx context.Context, tag string) ([]string, error) {
 	c.mutex.RLock()
@@ -445,6 +553,105 @@ func (c *FileCache) notifyListeners(eventType EventType, key string) {
 	}
 }
 
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+func (c *FileCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.incrItem(key, delta, ttl)
+}
+
+// incrItem 为整数缓存项增加 delta，调用方需持有写锁
+func (c *FileCache) incrItem(key string, delta int64, ttl time.Duration) (int64, error) {
+	value := delta
+	item, err := c.readItem(key)
+	exists := err == nil
+	if exists && (item.Expiration == nil || time.Now().Before(*item.Expiration)) {
+		current, ok := toInt64(item.Value)
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrNotInteger, key)
+		}
+		value = current + delta
+	} else {
+		// 键不存在或已过期，以 delta 创建
+		item = &fileItem{}
+		if ttl <= 0 {
+			ttl = c.defaultTTL
+		}
+		if ttl > 0 {
+			expiration := time.Now().Add(ttl)
+			item.Expiration = &expiration
+		}
+	}
+	item.Value = value
+
+	data, err := c.serializer.Marshal(item)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrMarshal, err)
+	}
+
+	filePath := filepath.Join(c.directory, key)
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return 0, fmt.Errorf("failed to write cache file: %v", err)
+	}
+
+	if !exists {
+		c.stats.IncrKeyCount()
+	}
+	c.notifyListeners(EventTypeSet, key)
+
+	return value, nil
+}
+
+// Txn 在同一次加锁中执行事务，所有操作校验通过后才会写入
+// 写入文件失败时已完成的操作无法回滚
+func (c *FileCache) Txn(ctx context.Context, fn func(tx Txn) error) error {
+	ops, err := collectTxn(fn)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = validateTxn(ops, func(key string) (interface{}, bool, error) {
+		item, err := c.readItem(key)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, false, nil
+			}
+			return nil, false, fmt.Errorf("failed to read cache file: %v", err)
+		}
+		if item.Expiration != nil && time.Now().After(*item.Expiration) {
+			return nil, false, nil
+		}
+		return item.Value, true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		switch op.typ {
+		case txnSet:
+			err = c.setItem(op.key, op.value, op.ttl)
+		case txnDelete:
+			err = c.deleteItem(op.key)
+		case txnIncrBy:
+			_, err = c.incrItem(op.key, op.delta, op.ttl)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DefaultExpiration 返回默认过期时间
+func (c *FileCache) DefaultExpiration() time.Duration {
+	return c.defaultTTL
+}
+
 // ResetStats 重置统计信息
 func (c *FileCache) ResetStats(ctx context.Context) error {
 	c.stats.Reset()
@@ -517,6 +724,17 @@ func (l *FileLock) Refresh(ctx context.Context) error {
 	return fmt.Errorf("lock not found")
 }
 
+// checkWritable 通过创建临时文件检查缓存目录是否可写
+func (c *FileCache) checkWritable() error {
+	file, err := os.CreateTemp(c.directory, ".healthcheck-*")
+	if err != nil {
+		return fmt.Errorf("cache directory is not writable: %v", err)
+	}
+	name := file.Name()
+	file.Close()
+	return os.Remove(name)
+}
+
 // readItem 读取缓存项
 func (c *FileCache) readItem(key string) (*fileItem, error) {
 	filePath := filepath.Join(c.directory, key)
@@ -526,7 +744,7 @@ func (c *FileCache) readItem(key string) (*fileItem, error) {
 	}
 
 	var item fileItem
-	if err := json.Unmarshal(data, &item); err != nil {
+	if err := c.serializer.Unmarshal(data, &item); err != nil {
 		return nil, err
 	}
 
@@ -164,6 +164,37 @@ func TestFileCacheStats(t *testing.T) {
 	}
 }
 
+func TestFileCacheHealthCheckReadOnly(t *testing.T) {
+	tempDir := t.TempDir()
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := NewFileCache(config, &FileCacheConfig{Directory: tempDir})
+
+	if err := os.Chmod(tempDir, 0o555); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	defer os.Chmod(tempDir, 0o755)
+	// 以 root 运行时目录权限不生效
+	if f, err := os.CreateTemp(tempDir, "probe-*"); err == nil {
+		f.Close()
+		os.Remove(f.Name())
+		t.Skip("directory permissions are not enforced for the current user")
+	}
+
+	health, err := cache.HealthCheck(context.Background())
+	if err != nil {
+		t.Fatalf("HealthCheck failed: %v", err)
+	}
+	if health.Status != "unhealthy" {
+		t.Errorf("Expected status unhealthy, got %v", health.Status)
+	}
+	if _, ok := health.Details["error"]; !ok {
+		t.Error("Expected error detail for read-only directory")
+	}
+}
+
 func TestFileCacheLock(t *testing.T) {
 	// 创建临时目录
 	tempDir, err := os.MkdirTemp("", "cache_test")
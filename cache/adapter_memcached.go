@@ -0,0 +1,290 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+)
+
+// memcachedMaxRelativeTTL memcached 相对过期时间的上限，超过该值的过期时间会被当作 Unix 时间戳
+const memcachedMaxRelativeTTL = 30 * 24 * time.Hour
+
+// MemcachedCacheConfig Memcached 缓存配置
+type MemcachedCacheConfig struct {
+	// Addresses 服务器地址列表，键按一致性哈希分布到各服务器
+	Addresses []string `yaml:"addresses"`
+	// Timeout 读写超时时间，默认使用 gomemcache 的默认值
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxIdleConns 每个服务器保留的最大空闲连接数
+	MaxIdleConns int `yaml:"max_idle_conns"`
+}
+
+// MemcachedCache 基于 Memcached 的缓存实现
+// 值通过序列化器编码，IncrBy 使用服务端原生的 incr/decr
+// 键可能被服务端过期或驱逐而客户端无从得知，因此不统计键数量
+type MemcachedCache struct {
+	client     *memcache.Client
+	stats      *StatsCollector
+	defaultTTL time.Duration
+	slidingTTL bool
+	serializer Serializer
+}
+
+// NewMemcachedCache 创建 Memcached 缓存实例
+func NewMemcachedCache(config *BaseConfig, cacheConfig *MemcachedCacheConfig) (*MemcachedCache, error) {
+	if len(cacheConfig.Addresses) == 0 {
+		return nil, fmt.Errorf("memcached addresses are required")
+	}
+
+	serializer, err := newSerializer(config.Serializer)
+	if err != nil {
+		return nil, err
+	}
+
+	client := memcache.New(cacheConfig.Addresses...)
+	client.Timeout = cacheConfig.Timeout
+	client.MaxIdleConns = cacheConfig.MaxIdleConns
+
+	return &MemcachedCache{
+		client:     client,
+		stats:      NewStatsCollector(),
+		defaultTTL: config.DefaultExpiration,
+		slidingTTL: config.SlidingTTL,
+		serializer: serializer,
+	}, nil
+}
+
+// Set 设置缓存，ttl 不大于0时使用默认过期时间，默认过期时间也未设置时永不过期
+func (c *MemcachedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := c.serializer.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMarshal, err)
+	}
+
+	err = c.client.Set(&memcache.Item{
+		Key:        key,
+		Value:      data,
+		Expiration: c.expiration(ttl),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set cache: %v", err)
+	}
+	return nil
+}
+
+// Get 获取缓存，启用滑动过期时同时重置过期时间
+func (c *MemcachedCache) Get(ctx context.Context, key string, value interface{}) error {
+	var item *memcache.Item
+	var err error
+	if c.slidingTTL && c.defaultTTL > 0 {
+		item, err = c.client.GetAndTouch(key, c.expiration(c.defaultTTL))
+	} else {
+		item, err = c.client.Get(key)
+	}
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			c.stats.IncrMisses()
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get cache: %v", err)
+	}
+
+	if err := decodeValue(c.serializer, item.Value, value); err != nil {
+		return fmt.Errorf("failed to unmarshal cache value: %v", err)
+	}
+
+	c.stats.IncrHits()
+	return nil
+}
+
+// Delete 删除缓存
+func (c *MemcachedCache) Delete(ctx context.Context, key string) error {
+	if err := c.client.Delete(key); err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return fmt.Errorf("failed to delete cache: %v", err)
+	}
+	return nil
+}
+
+// Has 检查缓存是否存在，memcached 没有单独的存在性查询，通过读取实现
+func (c *MemcachedCache) Has(ctx context.Context, key string) (bool, error) {
+	_, err := c.client.Get(key)
+	if err != nil {
+		if errors.Is(err, memcache.ErrCacheMiss) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check cache: %v", err)
+	}
+	return true, nil
+}
+
+// Clear 清空所有服务器上的缓存
+func (c *MemcachedCache) Clear(ctx context.Context) error {
+	if err := c.client.FlushAll(); err != nil {
+		return fmt.Errorf("failed to clear cache: %v", err)
+	}
+
+	c.stats.Reset()
+	return nil
+}
+
+// GetStats 获取缓存统计信息，KeyCount 始终为0
+func (c *MemcachedCache) GetStats(ctx context.Context) (*Stats, error) {
+	stats := c.stats.GetStats()
+	return &stats, nil
+}
+
+// HealthCheck 执行健康检查，任一服务器不可用时返回 unhealthy
+func (c *MemcachedCache) HealthCheck(ctx context.Context) (*Health, error) {
+	start := time.Now()
+	if err := c.client.Ping(); err != nil {
+		return &Health{
+			Status:    "unhealthy",
+			Details:   map[string]interface{}{"error": err.Error()},
+			Timestamp: time.Now(),
+		}, nil
+	}
+	latency := time.Since(start)
+
+	stats := c.stats.GetStats()
+	return &Health{
+		Status: "healthy",
+		Details: map[string]interface{}{
+			"hits":    stats.Hits,
+			"misses":  stats.Misses,
+			"latency": latency.String(),
+		},
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// MSet 批量设置缓存，memcached 没有原生的批量写入，逐个写入
+func (c *MemcachedCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	for key, value := range items {
+		if err := c.Set(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MGet 通过原生的多键读取批量获取缓存，不存在的键不会出现在结果中
+func (c *MemcachedCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	items, err := c.client.GetMulti(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get multiple caches: %v", err)
+	}
+
+	result := make(map[string]interface{}, len(items))
+	for _, key := range keys {
+		item, ok := items[key]
+		if !ok {
+			c.stats.IncrMisses()
+			continue
+		}
+
+		var v interface{}
+		if err := decodeValue(c.serializer, item.Value, &v); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal value: %v", err)
+		}
+		result[key] = v
+		c.stats.IncrHits()
+	}
+	return result, nil
+}
+
+// MDelete 批量删除缓存
+func (c *MemcachedCache) MDelete(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		if err := c.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IncrBy 通过 incr/decr 原子地为整数缓存值增加 delta 并返回新值
+// memcached 计数器为无符号整数，减到0以下时结果为0；键不存在且 delta 为负时以0创建
+func (c *MemcachedCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	for {
+		value, err := c.incrDecr(key, delta)
+		if err == nil {
+			return value, nil
+		}
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			if strings.Contains(err.Error(), "non-numeric") {
+				return 0, fmt.Errorf("%w: %s", ErrNotInteger, key)
+			}
+			return 0, fmt.Errorf("failed to increment cache: %v", err)
+		}
+
+		// 键不存在时创建，其他客户端抢先创建时重新执行增减
+		initial := delta
+		if initial < 0 {
+			initial = 0
+		}
+		err = c.client.Add(&memcache.Item{
+			Key:        key,
+			Value:      []byte(strconv.FormatInt(initial, 10)),
+			Expiration: c.expiration(ttl),
+		})
+		if err == nil {
+			return initial, nil
+		}
+		if !errors.Is(err, memcache.ErrNotStored) {
+			return 0, fmt.Errorf("failed to increment cache: %v", err)
+		}
+	}
+}
+
+// incrDecr 根据 delta 的符号执行 incr 或 decr
+func (c *MemcachedCache) incrDecr(key string, delta int64) (int64, error) {
+	var value uint64
+	var err error
+	if delta >= 0 {
+		value, err = c.client.Increment(key, uint64(delta))
+	} else {
+		value, err = c.client.Decrement(key, uint64(-delta))
+	}
+	return int64(value), err
+}
+
+// DefaultExpiration 返回默认过期时间
+func (c *MemcachedCache) DefaultExpiration() time.Duration {
+	return c.defaultTTL
+}
+
+// ResetStats 重置统计信息
+func (c *MemcachedCache) ResetStats(ctx context.Context) error {
+	c.stats.Reset()
+	return nil
+}
+
+// Close 关闭所有连接
+func (c *MemcachedCache) Close() error {
+	return c.client.Close()
+}
+
+// expiration 将过期时间转换为 memcached 的过期秒数
+// ttl 不大于0时使用默认过期时间；不足1秒按1秒处理；超过30天时转换为 Unix 时间戳
+func (c *MemcachedCache) expiration(ttl time.Duration) int32 {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	if ttl > memcachedMaxRelativeTTL {
+		return int32(time.Now().Add(ttl).Unix())
+	}
+
+	seconds := int32(ttl / time.Second)
+	if ttl%time.Second != 0 {
+		seconds++
+	}
+	return seconds
+}
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestMemcachedCache(t *testing.T) *MemcachedCache {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache, err := NewMemcachedCache(config, &MemcachedCacheConfig{
+		Addresses: []string{"localhost:11211"},
+		Timeout:   100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewMemcachedCache failed: %v", err)
+	}
+
+	health, _ := cache.HealthCheck(context.Background())
+	if health.Status != "healthy" {
+		cache.Close()
+		t.Skip("Memcached server is not available")
+	}
+	t.Cleanup(func() { cache.Close() })
+	return cache
+}
+
+func TestMemcachedCache(t *testing.T) {
+	cache := newTestMemcachedCache(t)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, "memcached:key", "value", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	var value string
+	if err := cache.Get(ctx, "memcached:key", &value); err != nil || value != "value" {
+		t.Errorf("Expected value, got %q (%v)", value, err)
+	}
+
+	values, err := cache.MGet(ctx, []string{"memcached:key", "memcached:missing"})
+	if err != nil {
+		t.Fatalf("MGet failed: %v", err)
+	}
+	if len(values) != 1 || values["memcached:key"] != "value" {
+		t.Errorf("Unexpected MGet result: %v", values)
+	}
+
+	if err := cache.Delete(ctx, "memcached:key"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := cache.Get(ctx, "memcached:key", &value); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	// 不统计键数量，删除不存在的键不影响 KeyCount
+	if err := cache.Delete(ctx, "memcached:key"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	stats, _ := cache.GetStats(ctx)
+	if stats.KeyCount != 0 {
+		t.Errorf("Expected KeyCount 0, got %d", stats.KeyCount)
+	}
+}
+
+func TestMemcachedIncrBy(t *testing.T) {
+	cache := newTestMemcachedCache(t)
+	ctx := context.Background()
+	cache.Delete(ctx, "memcached:counter")
+
+	if n, err := cache.IncrBy(ctx, "memcached:counter", 5, time.Minute); err != nil || n != 5 {
+		t.Errorf("Expected 5, got %d (%v)", n, err)
+	}
+	if n, err := DecrBy(ctx, cache, "memcached:counter", 2); err != nil || n != 3 {
+		t.Errorf("Expected 3, got %d (%v)", n, err)
+	}
+	var n int64
+	if err := cache.Get(ctx, "memcached:counter", &n); err != nil || n != 3 {
+		t.Errorf("Expected counter 3, got %d (%v)", n, err)
+	}
+
+	cache.Set(ctx, "memcached:text", "abc", time.Minute)
+	if _, err := cache.IncrBy(ctx, "memcached:text", 1, 0); !errors.Is(err, ErrNotInteger) {
+		t.Errorf("Expected ErrNotInteger, got %v", err)
+	}
+}
+
+func TestMemcachedExpiration(t *testing.T) {
+	cache := &MemcachedCache{defaultTTL: time.Minute}
+
+	if got := cache.expiration(0); got != 60 {
+		t.Errorf("Expected default expiration 60, got %d", got)
+	}
+	if got := cache.expiration(1500 * time.Millisecond); got != 2 {
+		t.Errorf("Expected rounded expiration 2, got %d", got)
+	}
+	if got := cache.expiration(60 * 24 * time.Hour); int64(got) < time.Now().Unix() {
+		t.Errorf("Expected absolute expiration for long ttl, got %d", got)
+	}
+
+	cache.defaultTTL = 0
+	if got := cache.expiration(0); got != 0 {
+		t.Errorf("Expected no expiration, got %d", got)
+	}
+}
@@ -2,9 +2,11 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -12,6 +14,8 @@ import (
 type MemoryCacheConfig struct {
 	// Policy 缓存策略：lru, fifo
 	Policy string `yaml:"policy"`
+	// MaxBytes 最大占用字节数，按缓存项序列化后的近似大小计算，0 表示不限制
+	MaxBytes int64 `yaml:"max_bytes"`
 }
 
 // MemoryCache 内存存储实现
@@ -20,12 +24,17 @@ type MemoryCache struct {
 	data            map[string]*memoryItem
 	tags            map[string][]string
 	maxSize         int
+	maxBytes        int64
+	usedBytes       int64
 	cleanupInterval time.Duration
+	defaultTTL      time.Duration
+	slidingTTL      bool
 	stopCleanup     chan bool
 	stats           *StatsCollector
 	policy          Policy
 	config          *MemoryCacheConfig
 	listeners       []EventListener
+	keyLocks        keyMutex
 }
 
 // item 缓存项
@@ -33,6 +42,11 @@ type memoryItem struct {
 	value      interface{}
 	expiration *time.Time
 	tags       []string
+	size       int64
+	// updated 写入时间（UnixNano），在写锁下设置
+	updated int64
+	// accessed 最后一次读取时间（UnixNano），读取时在读锁下原子更新
+	accessed atomic.Int64
 }
 
 // NewMemoryCache 创建内存缓存实例
@@ -45,10 +59,16 @@ func NewMemoryCache(config *BaseConfig, cacheConfig *MemoryCacheConfig) *MemoryC
 		policy:          NewLRUPolicy(),
 		maxSize:         config.MaxSize,
 		cleanupInterval: time.Duration(config.CleanupInterval) * time.Second,
+		defaultTTL:      config.DefaultExpiration,
+		slidingTTL:      config.SlidingTTL,
 		stopCleanup:     make(chan bool),
 		listeners:       make([]EventListener, 0),
 	}
 
+	if cacheConfig != nil {
+		cache.maxBytes = cacheConfig.MaxBytes
+	}
+
 	// 启动清理协程
 	go cache.startCleanup()
 
@@ -60,9 +80,15 @@ func (c *MemoryCache) Set(ctx context.Context, key string, value interface{}, tt
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	c.setItem(key, value, ttl)
+	return nil
+}
+
+// setItem 写入缓存项，调用方需持有写锁
+func (c *MemoryCache) setItem(key string, value interface{}, ttl time.Duration) {
 	// 检查是否需要驱逐
 	if len(c.data) >= c.maxSize {
-		c.evictOne()
+		c.evictOne(evictByPolicy)
 	}
 
 	expiration := time.Now().Add(ttl)
@@ -71,16 +97,17 @@ func (c *MemoryCache) Set(ctx context.Context, key string, value interface{}, tt
 		expiration: &expiration,
 	}
 
+	c.trackSize(key, item)
 	c.data[key] = item
 	c.policy.Update(key, item)
 	c.stats.IncrKeyCount()
 	c.notifyListeners(EventTypeSet, key)
-
-	return nil
+	c.evictBytes()
 }
 
 // Get 获取缓存
 func (c *MemoryCache) Get(ctx context.Context, key string, value interface{}) error {
+	// 访问时间通过原子操作更新，读取只需要读锁
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
@@ -90,7 +117,8 @@ func (c *MemoryCache) Get(ctx context.Context, key string, value interface{}) er
 		return ErrNotFound
 	}
 
-	if item.expiration != nil && time.Now().After(*item.expiration) {
+	now := time.Now()
+	if c.expired(item, now) {
 		c.stats.IncrMisses()
 		c.stats.IncrExpiredCount()
 		return ErrNotFound
@@ -108,22 +136,51 @@ func (c *MemoryCache) Get(ctx context.Context, key string, value interface{}) er
 		cachedValue = cachedValue.Elem()
 	}
 
-	if !cachedValue.Type().AssignableTo(valueElem.Type()) {
+	// 类型不能直接赋值时（如从快照恢复的值）通过JSON转换
+	if cachedValue.Type().AssignableTo(valueElem.Type()) {
+		valueElem.Set(cachedValue)
+	} else if err := assignValue(value, cachedValue.Interface()); err != nil {
 		return fmt.Errorf("cannot assign cached value of type %v to value of type %v", cachedValue.Type(), valueElem.Type())
 	}
 
-	valueElem.Set(cachedValue)
+	item.accessed.Store(now.UnixNano())
 	c.stats.IncrHits()
 	c.notifyListeners(EventTypeGet, key)
 
 	return nil
 }
 
+// expiresAt 返回缓存项实际的过期时间，未设置过期时间时第二个返回值为 false
+// 启用滑动过期且缓存项被读取过时，过期时间为最后一次读取时间加默认过期时间
+func (c *MemoryCache) expiresAt(item *memoryItem) (time.Time, bool) {
+	if item.expiration == nil {
+		return time.Time{}, false
+	}
+	if c.slidingTTL && c.defaultTTL > 0 {
+		if accessed := item.accessed.Load(); accessed > 0 {
+			return time.Unix(0, accessed).Add(c.defaultTTL), true
+		}
+	}
+	return *item.expiration, true
+}
+
+// expired 检查缓存项在 now 时是否已过期
+func (c *MemoryCache) expired(item *memoryItem, now time.Time) bool {
+	expiration, ok := c.expiresAt(item)
+	return ok && now.After(expiration)
+}
+
 // Delete 删除缓存
 func (c *MemoryCache) Delete(ctx context.Context, key string) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	c.deleteItem(key)
+	return nil
+}
+
+// deleteItem 删除缓存项，调用方需持有写锁
+func (c *MemoryCache) deleteItem(key string) {
 	if item, exists := c.data[key]; exists {
 		// 删除标签关系
 		for _, tag := range item.tags {
@@ -137,12 +194,11 @@ func (c *MemoryCache) Delete(ctx context.Context, key string) error {
 			}
 		}
 
+		c.usedBytes -= item.size
 		delete(c.data, key)
 		c.stats.DecrKeyCount()
 		c.notifyListeners(EventTypeDelete, key)
 	}
-
-	return nil
 }
 
 // Has 检查缓存是否存在
@@ -155,7 +211,7 @@ func (c *MemoryCache) Has(ctx context.Context, key string) (bool, error) {
 		return false, nil
 	}
 
-	if item.expiration != nil && time.Now().After(*item.expiration) {
+	if c.expired(item, time.Now()) {
 		return false, nil
 	}
 
@@ -169,6 +225,7 @@ func (c *MemoryCache) Clear(ctx context.Context) error {
 
 	c.data = make(map[string]*memoryItem)
 	c.tags = make(map[string][]string)
+	c.usedBytes = 0
 	c.stats.Reset()
 	c.notifyListeners(EventTypeClear, "")
 
@@ -176,22 +233,55 @@ func (c *MemoryCache) Clear(ctx context.Context) error {
 }
 
 // GetStats 获取缓存统计信息
+// ExpiringWithin 需要在读锁下遍历全部缓存项，耗时与键数量成正比
 func (c *MemoryCache) GetStats(ctx context.Context) (*Stats, error) {
 	stats := c.stats.GetStats()
+	stats.ExpiringWithin = c.expiringWithin()
 	return &stats, nil
 }
 
+// expiringWithin 按 ExpiryBuckets 统计即将过期的键数量，已过期的键不计入
+func (c *MemoryCache) expiringWithin() map[time.Duration]int64 {
+	buckets := make(map[time.Duration]int64, len(ExpiryBuckets))
+	for _, bucket := range ExpiryBuckets {
+		buckets[bucket] = 0
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	now := time.Now()
+	for _, item := range c.data {
+		expiration, ok := c.expiresAt(item)
+		if !ok {
+			continue
+		}
+		remaining := expiration.Sub(now)
+		if remaining <= 0 {
+			continue
+		}
+		for _, bucket := range ExpiryBuckets {
+			if remaining <= bucket {
+				buckets[bucket]++
+			}
+		}
+	}
+	return buckets
+}
+
 // HealthCheck 执行健康检查
 func (c *MemoryCache) HealthCheck(ctx context.Context) (*Health, error) {
 	stats := c.stats.GetStats()
 	return &Health{
 		Status: "healthy",
 		Details: map[string]interface{}{
-			"key_count":     stats.KeyCount,
-			"hits":          stats.Hits,
-			"misses":        stats.Misses,
-			"evicted_count": stats.EvictedCount,
-			"expired_count": stats.ExpiredCount,
+			"key_count":         stats.KeyCount,
+			"hits":              stats.Hits,
+			"misses":            stats.Misses,
+			"evicted_count":     stats.EvictedCount,
+			"evicted_by_size":   stats.EvictedBySize,
+			"evicted_by_policy": stats.EvictedByPolicy,
+			"expired_count":     stats.ExpiredCount,
 		},
 		Timestamp: time.Now(),
 	}, nil
@@ -205,7 +295,7 @@ func (c *MemoryCache) MSet(ctx context.Context, items map[string]interface{}, tt
 	for key, value := range items {
 		// 检查是否需要驱逐
 		if len(c.data) >= c.maxSize {
-			c.evictOne()
+			c.evictOne(evictByPolicy)
 		}
 
 		expiration := time.Now().Add(ttl)
@@ -214,11 +304,13 @@ func (c *MemoryCache) MSet(ctx context.Context, items map[string]interface{}, tt
 			expiration: &expiration,
 		}
 
+		c.trackSize(key, item)
 		c.data[key] = item
 		c.policy.Update(key, item)
 		c.stats.IncrKeyCount()
 		c.notifyListeners(EventTypeSet, key)
 	}
+	c.evictBytes()
 
 	return nil
 }
@@ -228,6 +320,7 @@ func (c *MemoryCache) MGet(ctx context.Context, keys []string) (map[string]inter
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
+	now := time.Now()
 	result := make(map[string]interface{})
 	for _, key := range keys {
 		item, exists := c.data[key]
@@ -236,13 +329,14 @@ func (c *MemoryCache) MGet(ctx context.Context, keys []string) (map[string]inter
 			continue
 		}
 
-		if item.expiration != nil && time.Now().After(*item.expiration) {
+		if c.expired(item, now) {
 			c.stats.IncrMisses()
 			c.stats.IncrExpiredCount()
 			continue
 		}
 
 		result[key] = item.value
+		item.accessed.Store(now.UnixNano())
 		c.stats.IncrHits()
 		c.notifyListeners(EventTypeGet, key)
 	}
@@ -269,6 +363,7 @@ func (c *MemoryCache) MDelete(ctx context.Context, keys []string) error {
 				}
 			}
 
+			c.usedBytes -= item.size
 			delete(c.data, key)
 			c.stats.DecrKeyCount()
 			c.notifyListeners(EventTypeDelete, key)
@@ -285,7 +380,7 @@ func (c *MemoryCache) SetWithTags(ctx context.Context, key string, value interfa
 
 	// 检查是否需要驱逐
 	if len(c.data) >= c.maxSize {
-		c.evictOne()
+		c.evictOne(evictByPolicy)
 	}
 
 	expiration := time.Now().Add(ttl)
@@ -300,14 +395,70 @@ func (c *MemoryCache) SetWithTags(ctx context.Context, key string, value interfa
 		c.tags[tag] = append(c.tags[tag], key)
 	}
 
+	c.trackSize(key, item)
 	c.data[key] = item
 	c.policy.Update(key, item)
 	c.stats.IncrKeyCount()
 	c.notifyListeners(EventTypeSet, key)
+	c.evictBytes()
 
 	return nil
 }
 
+// MSetWithTags 批量设置带标签的缓存
+// 所有缓存项与标签索引在同一次加锁中完成更新，已存在的键会先移除旧的标签关系
+func (c *MemoryCache) MSetWithTags(ctx context.Context, items map[string]TaggedValue, ttl time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for key, tv := range items {
+		if old, exists := c.data[key]; exists {
+			// 重建标签索引
+			c.removeTagRefs(key, old.tags)
+		} else {
+			// 检查是否需要驱逐
+			if len(c.data) >= c.maxSize {
+				c.evictOne(evictByPolicy)
+			}
+			c.stats.IncrKeyCount()
+		}
+
+		expiration := time.Now().Add(ttl)
+		item := &memoryItem{
+			value:      tv.Value,
+			expiration: &expiration,
+			tags:       tv.Tags,
+		}
+
+		// 更新标签关系
+		for _, tag := range tv.Tags {
+			c.tags[tag] = append(c.tags[tag], key)
+		}
+
+		c.trackSize(key, item)
+		c.data[key] = item
+		c.policy.Update(key, item)
+		c.notifyListeners(EventTypeSet, key)
+	}
+	c.evictBytes()
+
+	return nil
+}
+
+// removeTagRefs 移除键的标签关系
+func (c *MemoryCache) removeTagRefs(key string, tags []string) {
+	for _, tag := range tags {
+		if keys, ok := c.tags[tag]; ok {
+			for i, k := range keys {
+				if k == key {
+					c.tags[tag] = append(keys[:i], keys[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+}
+
 // GetByTag 获取指定标签的所有缓存键
 func (c *MemoryCache) GetByTag(ctx context.Context, tag string) ([]string, error) {
 	c.mutex.RLock()
@@ -341,6 +492,7 @@ func (c *MemoryCache) DeleteByTag(ctx context.Context, tag string) error {
 					}
 				}
 
+				c.usedBytes -= item.size
 				delete(c.data, key)
 				c.stats.DecrKeyCount()
 				c.notifyListeners(EventTypeDelete, key)
@@ -378,6 +530,112 @@ func (c *MemoryCache) notifyListeners(eventType EventType, key string) {
 	}
 }
 
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+func (c *MemoryCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.incrItem(key, delta, ttl)
+}
+
+// incrItem 为整数缓存项增加 delta，调用方需持有写锁
+func (c *MemoryCache) incrItem(key string, delta int64, ttl time.Duration) (int64, error) {
+	item, exists := c.data[key]
+	if exists && !c.expired(item, time.Now()) {
+		current, ok := toInt64(item.value)
+		if !ok {
+			return 0, fmt.Errorf("%w: %s", ErrNotInteger, key)
+		}
+		item.value = current + delta
+		c.notifyListeners(EventTypeSet, key)
+		return current + delta, nil
+	}
+
+	// 键不存在或已过期，以 delta 创建
+	if !exists && len(c.data) >= c.maxSize {
+		c.evictOne(evictByPolicy)
+	}
+
+	item = &memoryItem{value: delta}
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+	if ttl > 0 {
+		expiration := time.Now().Add(ttl)
+		item.expiration = &expiration
+	}
+
+	c.trackSize(key, item)
+	c.data[key] = item
+	c.policy.Update(key, item)
+	if !exists {
+		c.stats.IncrKeyCount()
+	}
+	c.notifyListeners(EventTypeSet, key)
+	c.evictBytes()
+
+	return delta, nil
+}
+
+// Txn 在同一次加锁中执行事务，所有操作校验通过后才会写入
+func (c *MemoryCache) Txn(ctx context.Context, fn func(tx Txn) error) error {
+	ops, err := collectTxn(fn)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	err = validateTxn(ops, func(key string) (interface{}, bool, error) {
+		item, exists := c.data[key]
+		if !exists || c.expired(item, time.Now()) {
+			return nil, false, nil
+		}
+		return item.value, true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		switch op.typ {
+		case txnSet:
+			c.setItem(op.key, op.value, op.ttl)
+		case txnDelete:
+			c.deleteItem(op.key)
+		case txnIncrBy:
+			if _, err := c.incrItem(op.key, op.delta, op.ttl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// toInt64 将整数类型的缓存值转换为 int64
+func toInt64(value interface{}) (int64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		// JSON 解码后的数字为 float64，仅接受整数值
+		f := v.Float()
+		if f == float64(int64(f)) {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+// DefaultExpiration 返回默认过期时间
+func (c *MemoryCache) DefaultExpiration() time.Duration {
+	return c.defaultTTL
+}
+
 // ResetStats 重置统计信息
 func (c *MemoryCache) ResetStats(ctx context.Context) error {
 	c.stats.Reset()
@@ -398,7 +656,8 @@ func (l *MemoryLock) Lock(ctx context.Context) error {
 	defer l.cache.mutex.Unlock()
 
 	if item, exists := l.cache.data[l.key]; exists {
-		if item.expiration != nil && time.Now().After(*item.expiration) {
+		if l.cache.expired(item, time.Now()) {
+			l.cache.usedBytes -= item.size
 			delete(l.cache.data, l.key)
 		} else {
 			return fmt.Errorf("lock already exists")
@@ -446,32 +705,86 @@ func (l *MemoryLock) Refresh(ctx context.Context) error {
 	return fmt.Errorf("lock not found or value mismatch")
 }
 
-// evictOne 根据策略驱逐一个缓存项
-func (c *MemoryCache) evictOne() {
+// evictReason 驱逐原因
+type evictReason int
+
+const (
+	// evictByPolicy 条目数达到 MaxSize，由驱逐策略选择缓存项
+	evictByPolicy evictReason = iota
+	// evictBySize 占用字节数超出 MaxBytes
+	evictBySize
+)
+
+// evictOne 根据策略驱逐一个缓存项，策略中没有可驱逐的键时返回 false
+// 策略中可能残留已被删除或清理的键，跳过这些键直到真正驱逐一个仍在缓存中的项
+func (c *MemoryCache) evictOne(reason evictReason) bool {
 	if c.policy == nil {
 		c.policy = NewLRUPolicy()
 	}
-	key := c.policy.Evict(c.data)
-	if key != "" {
-		if item, exists := c.data[key]; exists {
-			// 删除标签关系
-			for _, tag := range item.tags {
-				if keys, ok := c.tags[tag]; ok {
-					for i, k := range keys {
-						if k == key {
-							c.tags[tag] = append(keys[:i], keys[i+1:]...)
-							break
-						}
+	for {
+		key := c.policy.Evict(c.data)
+		if key == "" {
+			return false
+		}
+		item, exists := c.data[key]
+		if !exists {
+			continue
+		}
+
+		// 删除标签关系
+		for _, tag := range item.tags {
+			if keys, ok := c.tags[tag]; ok {
+				for i, k := range keys {
+					if k == key {
+						c.tags[tag] = append(keys[:i], keys[i+1:]...)
+						break
 					}
 				}
 			}
+		}
 
-			delete(c.data, key)
-			c.stats.DecrKeyCount()
-			c.stats.IncrEvictedCount()
-			c.notifyListeners(EventTypeDelete, key)
+		c.usedBytes -= item.size
+		delete(c.data, key)
+		c.stats.DecrKeyCount()
+		c.stats.IncrEvictedCount()
+		if reason == evictBySize {
+			c.stats.IncrEvictedBySize()
+		} else {
+			c.stats.IncrEvictedByPolicy()
 		}
+		c.notifyListeners(EventTypeDelete, key)
+		return true
+	}
+}
+
+// trackSize 记录写入时间，计算缓存项的近似大小并更新已用字节数，调用方需持有写锁
+func (c *MemoryCache) trackSize(key string, item *memoryItem) {
+	item.updated = time.Now().UnixNano()
+	item.size = estimateSize(key, item.value)
+	if old, exists := c.data[key]; exists {
+		c.usedBytes -= old.size
+	}
+	c.usedBytes += item.size
+}
+
+// evictBytes 超出字节预算时按策略驱逐缓存项，至少保留一项
+func (c *MemoryCache) evictBytes() {
+	for c.maxBytes > 0 && c.usedBytes > c.maxBytes && len(c.data) > 1 {
+		if !c.evictOne(evictBySize) {
+			return
+		}
+	}
+}
+
+// estimateSize 以JSON序列化后的长度估算缓存项大小
+func estimateSize(key string, value interface{}) int64 {
+	if data, err := json.Marshal(value); err == nil {
+		return int64(len(key) + len(data))
+	}
+	if value == nil {
+		return int64(len(key))
 	}
+	return int64(len(key)) + int64(reflect.TypeOf(value).Size())
 }
 
 // startCleanup 启动清理协程
@@ -496,7 +809,7 @@ func (c *MemoryCache) deleteExpired() {
 
 	now := time.Now()
 	for key, item := range c.data {
-		if item.expiration != nil && now.After(*item.expiration) {
+		if c.expired(item, now) {
 			// 删除标签关系
 			for _, tag := range item.tags {
 				if keys, ok := c.tags[tag]; ok {
@@ -509,6 +822,7 @@ func (c *MemoryCache) deleteExpired() {
 				}
 			}
 
+			c.usedBytes -= item.size
 			delete(c.data, key)
 			c.stats.DecrKeyCount()
 			c.stats.IncrExpiredCount()
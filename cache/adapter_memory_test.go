@@ -2,6 +2,10 @@ package cache
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -180,3 +184,449 @@ func TestMemoryCacheLock(t *testing.T) {
 		t.Errorf("Unlock failed: %v", err)
 	}
 }
+
+func TestMemoryCacheMSetWithTags(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cacheConfig := &MemoryCacheConfig{
+		Policy: "lru",
+	}
+	cache := NewMemoryCache(config, cacheConfig)
+
+	ctx := context.Background()
+	items := map[string]TaggedValue{
+		"user:1": {Value: "alice", Tags: []string{"users"}},
+		"user:2": {Value: "bob", Tags: []string{"users", "admins"}},
+		"user:3": {Value: "carol", Tags: []string{"users"}},
+	}
+
+	// 测试 MSetWithTags
+	if err := cache.MSetWithTags(ctx, items, time.Minute); err != nil {
+		t.Fatalf("MSetWithTags failed: %v", err)
+	}
+
+	// 测试 GetByTag
+	keys, err := cache.GetByTag(ctx, "users")
+	if err != nil {
+		t.Errorf("GetByTag failed: %v", err)
+	}
+	if len(keys) != len(items) {
+		t.Errorf("Expected %d keys, got %v", len(items), keys)
+	}
+	for _, key := range keys {
+		var result string
+		if err := cache.Get(ctx, key, &result); err != nil {
+			t.Errorf("Get %s failed: %v", key, err)
+		}
+		if result != items[key].Value {
+			t.Errorf("Expected %v, got %v", items[key].Value, result)
+		}
+	}
+
+	// 测试重建标签索引
+	if err := cache.MSetWithTags(ctx, map[string]TaggedValue{
+		"user:2": {Value: "bob", Tags: []string{"users"}},
+	}, time.Minute); err != nil {
+		t.Fatalf("MSetWithTags failed: %v", err)
+	}
+	keys, _ = cache.GetByTag(ctx, "admins")
+	if len(keys) != 0 {
+		t.Errorf("Expected no admins after reindex, got %v", keys)
+	}
+	keys, _ = cache.GetByTag(ctx, "users")
+	if len(keys) != len(items) {
+		t.Errorf("Expected %d keys after reindex, got %v", len(items), keys)
+	}
+}
+
+func TestMemoryCacheSlidingTTL(t *testing.T) {
+	ctx := context.Background()
+	ttl := 50 * time.Millisecond
+
+	for _, sliding := range []bool{true, false} {
+		config := &BaseConfig{
+			MaxSize:           100,
+			CleanupInterval:   60,
+			DefaultExpiration: ttl,
+			SlidingTTL:        sliding,
+		}
+		cache := NewMemoryCache(config, &MemoryCacheConfig{})
+
+		key := "session"
+		if err := cache.Set(ctx, key, "data", ttl); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+
+		// 在过期前反复读取，总时长超过原始TTL
+		var err error
+		for i := 0; i < 5; i++ {
+			time.Sleep(ttl / 2)
+			var result string
+			if err = cache.Get(ctx, key, &result); err != nil {
+				break
+			}
+		}
+
+		if sliding && err != nil {
+			t.Errorf("Expected key to survive with sliding TTL, got %v", err)
+		}
+		if !sliding && err != ErrNotFound {
+			t.Errorf("Expected key to expire without sliding TTL, got %v", err)
+		}
+	}
+}
+
+func TestMemoryCacheIncrBy(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	// 测试首次创建
+	value, err := cache.IncrBy(ctx, "counter", 5, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("IncrBy failed: %v", err)
+	}
+	if value != 5 {
+		t.Errorf("Expected 5, got %v", value)
+	}
+
+	// 测试累加并保留原有过期时间
+	value, err = cache.IncrBy(ctx, "counter", -2, time.Hour)
+	if err != nil {
+		t.Fatalf("IncrBy failed: %v", err)
+	}
+	if value != 3 {
+		t.Errorf("Expected 3, got %v", value)
+	}
+	time.Sleep(60 * time.Millisecond)
+	if exists, _ := cache.Has(ctx, "counter"); exists {
+		t.Error("Expected counter to keep its original TTL and expire")
+	}
+
+	// 测试非整数值
+	if err := cache.Set(ctx, "name", "alice", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := cache.IncrBy(ctx, "name", 1, time.Minute); err == nil {
+		t.Error("Expected error when incrementing a non-integer value")
+	}
+}
+
+func TestMemoryCacheMaxBytes(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{
+		Policy:   "lru",
+		MaxBytes: 3000,
+	})
+	ctx := context.Background()
+
+	// 每项约1KB，条目数远未达到 MaxSize，但字节数超出预算
+	value := strings.Repeat("x", 1000)
+	for i := 0; i < 5; i++ {
+		if err := cache.Set(ctx, fmt.Sprintf("key%d", i), value, time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	stats, _ := cache.GetStats(ctx)
+	if stats.EvictedCount == 0 {
+		t.Fatal("Expected eviction by bytes before reaching MaxSize")
+	}
+	if exists, _ := cache.Has(ctx, "key0"); exists {
+		t.Error("Expected oldest key to be evicted")
+	}
+	if exists, _ := cache.Has(ctx, "key4"); !exists {
+		t.Error("Expected newest key to be kept")
+	}
+	if cache.usedBytes > 3000 {
+		t.Errorf("Expected used bytes within budget, got %d", cache.usedBytes)
+	}
+}
+
+func TestMemoryCacheEvictionReasons(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         3,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{
+		Policy:   "lru",
+		MaxBytes: 2500,
+	})
+	ctx := context.Background()
+
+	// 小值超出 MaxSize，由驱逐策略驱逐
+	for i := 0; i < 5; i++ {
+		if err := cache.Set(ctx, fmt.Sprintf("small%d", i), i, time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+	stats, _ := cache.GetStats(ctx)
+	if stats.EvictedByPolicy != 2 || stats.EvictedBySize != 0 {
+		t.Errorf("Expected 2 policy evictions and no size evictions, got policy=%d size=%d",
+			stats.EvictedByPolicy, stats.EvictedBySize)
+	}
+
+	// 大值超出 MaxBytes，按字节预算驱逐
+	value := strings.Repeat("x", 1000)
+	if err := cache.Set(ctx, "large0", value, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Set(ctx, "large1", value, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := cache.Set(ctx, "large2", value, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	stats, _ = cache.GetStats(ctx)
+	if stats.EvictedBySize == 0 {
+		t.Error("Expected size evictions after exceeding MaxBytes")
+	}
+	if stats.EvictedCount != stats.EvictedByPolicy+stats.EvictedBySize {
+		t.Errorf("Expected total evictions %d to equal policy+size %d",
+			stats.EvictedCount, stats.EvictedByPolicy+stats.EvictedBySize)
+	}
+}
+
+func TestMemoryCacheMaxSizeAfterDelete(t *testing.T) {
+	for _, policy := range []string{"lru", "fifo"} {
+		t.Run(policy, func(t *testing.T) {
+			config := &BaseConfig{
+				MaxSize:         3,
+				CleanupInterval: 60,
+			}
+			cache := NewMemoryCache(config, &MemoryCacheConfig{Policy: policy})
+			ctx := context.Background()
+
+			for _, key := range []string{"a", "b", "c"} {
+				if err := cache.Set(ctx, key, key, time.Minute); err != nil {
+					t.Fatalf("Set failed: %v", err)
+				}
+			}
+			// 已删除的键仍留在策略中，驱逐时需要跳过
+			if err := cache.Delete(ctx, "a"); err != nil {
+				t.Fatalf("Delete failed: %v", err)
+			}
+			for _, key := range []string{"d", "e", "f"} {
+				if err := cache.Set(ctx, key, key, time.Minute); err != nil {
+					t.Fatalf("Set failed: %v", err)
+				}
+			}
+
+			cache.mutex.RLock()
+			size := len(cache.data)
+			cache.mutex.RUnlock()
+			if size > config.MaxSize {
+				t.Errorf("Expected at most %d items, got %d", config.MaxSize, size)
+			}
+			if exists, _ := cache.Has(ctx, "f"); !exists {
+				t.Error("Expected latest key to be stored")
+			}
+		})
+	}
+}
+
+func TestMemoryCacheExpiringWithin(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	ttls := map[string]time.Duration{
+		"a": 30 * time.Second,
+		"b": 3 * time.Minute,
+		"c": 4 * time.Minute,
+		"d": 30 * time.Minute,
+		"e": 2 * time.Hour,
+	}
+	for key, ttl := range ttls {
+		if err := cache.Set(ctx, key, key, ttl); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	stats, _ := cache.GetStats(ctx)
+	expected := map[time.Duration]int64{
+		time.Minute:     1,
+		5 * time.Minute: 3,
+		time.Hour:       4,
+	}
+	for bucket, want := range expected {
+		if got := stats.ExpiringWithin[bucket]; got != want {
+			t.Errorf("Expected %d keys expiring within %v, got %d", want, bucket, got)
+		}
+	}
+}
+
+func TestMemoryCacheLRUReadOrder(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         3,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{Policy: "lru"})
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := cache.Set(ctx, key, key, time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// 读取最早写入的键后，它不再是最久未使用的
+	var value string
+	if err := cache.Get(ctx, "a", &value); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := cache.Set(ctx, "d", "d", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	if exists, _ := cache.Has(ctx, "a"); !exists {
+		t.Error("Expected recently read key to survive eviction")
+	}
+	if exists, _ := cache.Has(ctx, "b"); exists {
+		t.Error("Expected least recently used key to be evicted")
+	}
+}
+
+func TestMemoryCacheTxn(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	cache.Set(ctx, "stock", 10, time.Minute)
+	cache.Set(ctx, "name", "widget", time.Minute)
+
+	// 计数器操作校验失败时，其他操作都不会写入
+	err := cache.Txn(ctx, func(tx Txn) error {
+		tx.IncrBy("stock", -1, 0)
+		tx.Set("order:1", "pending", time.Minute)
+		tx.IncrBy("name", 1, 0)
+		return nil
+	})
+	if !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("Expected ErrInvalidValue, got %v", err)
+	}
+	var stock int
+	cache.Get(ctx, "stock", &stock)
+	if stock != 10 {
+		t.Errorf("Expected stock to stay 10, got %d", stock)
+	}
+	if exists, _ := cache.Has(ctx, "order:1"); exists {
+		t.Error("Expected order not to be written")
+	}
+
+	// 事务函数返回错误时不写入
+	cache.Txn(ctx, func(tx Txn) error {
+		tx.Set("order:2", "pending", time.Minute)
+		return errors.New("abort")
+	})
+	if exists, _ := cache.Has(ctx, "order:2"); exists {
+		t.Error("Expected aborted transaction not to write")
+	}
+
+	// 成功提交
+	err = cache.Txn(ctx, func(tx Txn) error {
+		tx.IncrBy("stock", -1, 0)
+		tx.Set("order:3", "pending", time.Minute)
+		tx.Delete("name")
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Txn failed: %v", err)
+	}
+	var remaining int64
+	cache.Get(ctx, "stock", &remaining)
+	if remaining != 9 {
+		t.Errorf("Expected stock 9, got %d", remaining)
+	}
+	if exists, _ := cache.Has(ctx, "order:3"); !exists {
+		t.Error("Expected order to be written")
+	}
+	if exists, _ := cache.Has(ctx, "name"); exists {
+		t.Error("Expected name to be deleted")
+	}
+}
+
+// BenchmarkMemoryCacheGetParallel 并发读取的吞吐量
+// serialized=true 在每次读取外加一把互斥锁，模拟每次命中都需要独占锁的旧实现，作为对照
+func BenchmarkMemoryCacheGetParallel(b *testing.B) {
+	for _, sliding := range []bool{false, true} {
+		for _, serialized := range []bool{true, false} {
+			b.Run(fmt.Sprintf("sliding=%v/serialized=%v", sliding, serialized), func(b *testing.B) {
+				config := &BaseConfig{
+					MaxSize:           10000,
+					CleanupInterval:   60,
+					DefaultExpiration: time.Hour,
+					SlidingTTL:        sliding,
+				}
+				cache := NewMemoryCache(config, &MemoryCacheConfig{Policy: "lru"})
+				ctx := context.Background()
+				keys := make([]string, 1000)
+				for i := range keys {
+					keys[i] = fmt.Sprintf("key%d", i)
+					if err := cache.Set(ctx, keys[i], i, time.Hour); err != nil {
+						b.Fatalf("Set failed: %v", err)
+					}
+				}
+
+				var mu sync.Mutex
+				get := func(key string, value *int) {
+					if serialized {
+						mu.Lock()
+						defer mu.Unlock()
+					}
+					_ = cache.Get(ctx, key, value)
+				}
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					var value int
+					i := 0
+					for pb.Next() {
+						get(keys[i%len(keys)], &value)
+						i++
+					}
+				})
+			})
+		}
+	}
+}
+
+// BenchmarkMemoryCacheSetAtCapacity 缓存已满时写入新键，每次写入都会驱逐一个键，耗时不应随容量增长
+func BenchmarkMemoryCacheSetAtCapacity(b *testing.B) {
+	for _, size := range []int{1000, 100000} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			config := &BaseConfig{
+				MaxSize:         size,
+				CleanupInterval: 60,
+			}
+			cache := NewMemoryCache(config, &MemoryCacheConfig{Policy: "lru"})
+			ctx := context.Background()
+			for i := 0; i < size; i++ {
+				if err := cache.Set(ctx, fmt.Sprintf("key%d", i), i, time.Hour); err != nil {
+					b.Fatalf("Set failed: %v", err)
+				}
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = cache.Set(ctx, fmt.Sprintf("new%d", i), i, time.Hour)
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package cache
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,13 +22,25 @@ type RedisCacheConfig struct {
 
 // RedisCache Redis存储实现
 type RedisCache struct {
-	client    *redis.Client
-	stats     *StatsCollector
-	listeners []EventListener
-	mutex     sync.RWMutex
-	maxItems  int // 最大缓存项数量
+	client     *redis.Client
+	stats      *StatsCollector
+	listeners  []EventListener
+	mutex      sync.RWMutex
+	maxItems   int           // 最大缓存项数量
+	defaultTTL time.Duration // 默认过期时间
+	slidingTTL bool          // 是否启用滑动过期
+	serializer Serializer    // 值序列化器
 }
 
+// slidingGetScript 获取缓存值，并在键已设置过期时间时重置过期时间
+var slidingGetScript = redis.NewScript(`
+local value = redis.call('GET', KEYS[1])
+if value and redis.call('PTTL', KEYS[1]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return value
+`)
+
 // NewRedisCache 创建Redis缓存实例
 func NewRedisCache(config *BaseConfig, cacheConfig *RedisCacheConfig) *RedisCache {
 	client := redis.NewClient(&redis.Options{
@@ -38,10 +50,13 @@ func NewRedisCache(config *BaseConfig, cacheConfig *RedisCacheConfig) *RedisCach
 	})
 
 	return &RedisCache{
-		client:    client,
-		stats:     NewStatsCollector(),
-		listeners: make([]EventListener, 0),
-		maxItems:  config.MaxSize,
+		client:     client,
+		stats:      NewStatsCollector(),
+		listeners:  make([]EventListener, 0),
+		maxItems:   config.MaxSize,
+		defaultTTL: config.DefaultExpiration,
+		slidingTTL: config.SlidingTTL,
+		serializer: serializerOrDefault(config.Serializer),
 	}
 }
 
@@ -67,9 +82,9 @@ func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl
 		c.client.Del(ctx, oldestKey)
 	}
 	// 序列化值
-	data, err := json.Marshal(value)
+	data, err := c.serializer.Marshal(value)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %v", ErrMarshal, err)
 	}
 
 	// 存储序列化后的数据
@@ -85,7 +100,7 @@ func (c *RedisCache) Set(ctx context.Context, key string, value interface{}, ttl
 
 // Get 获取缓存
 func (c *RedisCache) Get(ctx context.Context, key string, value interface{}) error {
-	data, err := c.client.Get(ctx, key).Bytes()
+	data, err := c.getBytes(ctx, key)
 	if err != nil {
 		if err == redis.Nil {
 			return ErrNotFound
@@ -93,7 +108,7 @@ func (c *RedisCache) Get(ctx context.Context, key string, value interface{}) err
 		return fmt.Errorf("failed to get cache: %v", err)
 	}
 
-	if err := json.Unmarshal(data, value); err != nil {
+	if err := decodeValue(c.serializer, data, value); err != nil {
 		return fmt.Errorf("failed to unmarshal cache value: %v", err)
 	}
 
@@ -102,6 +117,19 @@ func (c *RedisCache) Get(ctx context.Context, key string, value interface{}) err
 	return nil
 }
 
+// getBytes 读取原始缓存数据，启用滑动过期时同时重置过期时间
+func (c *RedisCache) getBytes(ctx context.Context, key string) ([]byte, error) {
+	if !c.slidingTTL || c.defaultTTL <= 0 {
+		return c.client.Get(ctx, key).Bytes()
+	}
+
+	value, err := slidingGetScript.Run(ctx, c.client, []string{key}, c.defaultTTL.Milliseconds()).Text()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
+
 // Delete 删除缓存
 func (c *RedisCache) Delete(ctx context.Context, key string) error {
 	if err := c.client.Del(ctx, key).Err(); err != nil {
@@ -138,9 +166,9 @@ func (c *RedisCache) Clear(ctx context.Context) error {
 func (c *RedisCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
 	pipe := c.client.Pipeline()
 	for key, value := range items {
-		data, err := json.Marshal(value)
+		data, err := c.serializer.Marshal(value)
 		if err != nil {
-			return fmt.Errorf("failed to marshal value: %v", err)
+			return fmt.Errorf("%w: %v", ErrMarshal, err)
 		}
 		pipe.Set(ctx, key, data, ttl)
 	}
@@ -171,7 +199,7 @@ func (c *RedisCache) MGet(ctx context.Context, keys []string) (map[string]interf
 		}
 
 		var v interface{}
-		if err := json.Unmarshal([]byte(value.(string)), &v); err != nil {
+		if err := decodeValue(c.serializer, []byte(value.(string)), &v); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal value: %v", err)
 		}
 
@@ -203,17 +231,111 @@ func (c *RedisCache) SetWithTags(ctx context.Context, key string, value interfac
 		return err
 	}
 
-	// 设置标签关系
+	// 设置标签关系，同时记录键所属的标签
 	for _, tag := range tags {
 		tagKey := fmt.Sprintf("tag:%s", tag)
 		if err := c.client.SAdd(ctx, tagKey, key).Err(); err != nil {
 			return fmt.Errorf("failed to set tag: %v", err)
 		}
+		if err := c.client.SAdd(ctx, tagIndexKey(key), tag).Err(); err != nil {
+			return fmt.Errorf("failed to set tag: %v", err)
+		}
 		if ttl > 0 {
 			c.client.Expire(ctx, tagKey, ttl)
+			c.client.Expire(ctx, tagIndexKey(key), ttl)
+		}
+	}
+
+	return nil
+}
+
+// tagIndexKey 返回记录键所属标签的集合，覆盖或删除键时据此移除旧的标签关系
+func tagIndexKey(key string) string {
+	return fmt.Sprintf("tags:%s", key)
+}
+
+// MSetWithTags 批量设置带标签的缓存
+// 监视所有键及其标签记录并在同一个事务中提交：已存在的键先从原有标签集合中移除，再写入新值和新标签
+// 键计数只统计新增的键
+func (c *RedisCache) MSetWithTags(ctx context.Context, items map[string]TaggedValue, ttl time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(items))
+	watchKeys := make([]string, 0, 2*len(items))
+	values := make(map[string][]byte, len(items))
+	for key, tv := range items {
+		data, err := c.serializer.Marshal(tv.Value)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMarshal, err)
 		}
+		keys = append(keys, key)
+		watchKeys = append(watchKeys, key, tagIndexKey(key))
+		values[key] = data
 	}
 
+	var added int64
+	txf := func(tx *redis.Tx) error {
+		exists := make([]*redis.IntCmd, len(keys))
+		oldTags := make([]*redis.StringSliceCmd, len(keys))
+		if _, err := tx.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range keys {
+				exists[i] = pipe.Exists(ctx, key)
+				oldTags[i] = pipe.SMembers(ctx, tagIndexKey(key))
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to check cache: %v", err)
+		}
+
+		added = 0
+		for i := range keys {
+			if exists[i].Val() == 0 {
+				added++
+			}
+		}
+
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range keys {
+				for _, tag := range oldTags[i].Val() {
+					pipe.SRem(ctx, fmt.Sprintf("tag:%s", tag), key)
+				}
+				pipe.Del(ctx, tagIndexKey(key))
+
+				pipe.Set(ctx, key, values[key], ttl)
+				for _, tag := range items[key].Tags {
+					tagKey := fmt.Sprintf("tag:%s", tag)
+					pipe.SAdd(ctx, tagKey, key)
+					pipe.SAdd(ctx, tagIndexKey(key), tag)
+					if ttl > 0 {
+						pipe.Expire(ctx, tagKey, ttl)
+					}
+				}
+				if ttl > 0 && len(items[key].Tags) > 0 {
+					pipe.Expire(ctx, tagIndexKey(key), ttl)
+				}
+			}
+			return nil
+		})
+		return err
+	}
+
+	var err error
+	for i := 0; i < maxTxnRetries; i++ {
+		err = c.client.Watch(ctx, txf, watchKeys...)
+		if err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set multiple tagged caches: %v", err)
+	}
+
+	c.stats.IncrKeyCountBy(added)
+	for _, key := range keys {
+		c.notifyListeners(EventTypeSet, key)
+	}
 	return nil
 }
 
@@ -239,6 +361,9 @@ func (c *RedisCache) DeleteByTag(ctx context.Context, tag string) error {
 		if err := c.MDelete(ctx, keys); err != nil {
 			return err
 		}
+		if err := c.removeTagMembers(ctx, keys); err != nil {
+			return err
+		}
 	}
 
 	return c.client.Del(ctx, tagKey).Err()
@@ -252,7 +377,8 @@ func (c *RedisCache) GetStats(ctx context.Context) (*Stats, error) {
 
 // HealthCheck 执行健康检查
 func (c *RedisCache) HealthCheck(ctx context.Context) (*Health, error) {
-	// 检查Redis连接
+	// 检查Redis连接并记录延迟
+	start := time.Now()
 	if err := c.client.Ping(ctx).Err(); err != nil {
 		return &Health{
 			Status:    "unhealthy",
@@ -260,6 +386,7 @@ func (c *RedisCache) HealthCheck(ctx context.Context) (*Health, error) {
 			Timestamp: time.Now(),
 		}, nil
 	}
+	latency := time.Since(start)
 
 	stats := c.stats.GetStats()
 	return &Health{
@@ -268,6 +395,7 @@ func (c *RedisCache) HealthCheck(ctx context.Context) (*Health, error) {
 			"key_count": stats.KeyCount,
 			"hits":      stats.Hits,
 			"misses":    stats.Misses,
+			"latency":   latency.String(),
 		},
 		Timestamp: time.Now(),
 	}, nil
@@ -301,6 +429,129 @@ func (c *RedisCache) notifyListeners(eventType EventType, key string) {
 	}
 }
 
+// incrByScript 原子地增加计数，仅在键新建时设置过期时间
+var incrByScript = redis.NewScript(`
+local exists = redis.call('EXISTS', KEYS[1])
+local value = redis.call('INCRBY', KEYS[1], ARGV[1])
+if exists == 0 and tonumber(ARGV[2]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return value
+`)
+
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+func (c *RedisCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
+
+	value, err := incrByScript.Run(ctx, c.client, []string{key}, delta, ttl.Milliseconds()).Int64()
+	if err != nil {
+		if isNotIntegerError(err) {
+			return 0, fmt.Errorf("%w: %s", ErrNotInteger, key)
+		}
+		return 0, fmt.Errorf("failed to increment cache: %v", err)
+	}
+
+	c.notifyListeners(EventTypeSet, key)
+	return value, nil
+}
+
+// isNotIntegerError 判断是否为 Redis 返回的非整数值错误
+func isNotIntegerError(err error) bool {
+	return strings.Contains(err.Error(), "not an integer")
+}
+
+// Txn 通过 MULTI/EXEC 执行事务
+// 计数器操作涉及的键会被 WATCH 并预先校验，校验失败时不提交任何操作；
+// 提交期间键被其他客户端修改时会重试
+func (c *RedisCache) Txn(ctx context.Context, fn func(tx Txn) error) error {
+	ops, err := collectTxn(fn)
+	if err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	var watchKeys []string
+	for _, op := range ops {
+		if op.typ == txnIncrBy {
+			watchKeys = append(watchKeys, op.key)
+		}
+	}
+
+	txf := func(tx *redis.Tx) error {
+		err := validateTxn(ops, func(key string) (interface{}, bool, error) {
+			data, err := tx.Get(ctx, key).Bytes()
+			if err == redis.Nil {
+				return nil, false, nil
+			}
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to get cache: %v", err)
+			}
+			var value interface{}
+			if err := decodeValue(c.serializer, data, &value); err != nil {
+				return data, true, nil
+			}
+			return value, true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, op := range ops {
+				switch op.typ {
+				case txnSet:
+					data, err := c.serializer.Marshal(op.value)
+					if err != nil {
+						return fmt.Errorf("%w: %v", ErrMarshal, err)
+					}
+					pipe.Set(ctx, op.key, data, op.ttl)
+				case txnDelete:
+					pipe.Del(ctx, op.key)
+				case txnIncrBy:
+					ttl := op.ttl
+					if ttl <= 0 {
+						ttl = c.defaultTTL
+					}
+					incrByScript.Eval(ctx, pipe, []string{op.key}, op.delta, ttl.Milliseconds())
+				}
+			}
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < maxTxnRetries; i++ {
+		err = c.client.Watch(ctx, txf, watchKeys...)
+		if err != redis.TxFailedErr {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to execute transaction: %w", err)
+	}
+
+	for _, op := range ops {
+		if op.typ == txnDelete {
+			c.notifyListeners(EventTypeDelete, op.key)
+		} else {
+			c.notifyListeners(EventTypeSet, op.key)
+		}
+	}
+	return nil
+}
+
+// maxTxnRetries 事务因键被并发修改而失败时的最大重试次数
+const maxTxnRetries = 3
+
+// DefaultExpiration 返回默认过期时间
+func (c *RedisCache) DefaultExpiration() time.Duration {
+	return c.defaultTTL
+}
+
 // ResetStats 重置统计信息
 func (c *RedisCache) ResetStats(ctx context.Context) error {
 	c.stats.Reset()
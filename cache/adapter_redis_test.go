@@ -171,6 +171,9 @@ func TestRedisCacheStats(t *testing.T) {
 	if health.Status != "healthy" {
 		t.Errorf("Expected status healthy, got %v", health.Status)
 	}
+	if _, ok := health.Details["latency"]; !ok {
+		t.Error("Expected latency in health details")
+	}
 }
 
 func TestRedisCacheLock(t *testing.T) {
@@ -216,3 +219,101 @@ func TestRedisCacheLock(t *testing.T) {
 		t.Errorf("Unlock failed: %v", err)
 	}
 }
+
+func TestRedisCacheLockKey(t *testing.T) {
+	if !checkRedisConnection() {
+		t.Skip("Redis server is not available")
+	}
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cacheConfig := &RedisCacheConfig{
+		Addr:     "localhost:6379",
+		Password: "",
+		DB:       0,
+	}
+	cache := NewRedisCache(config, cacheConfig)
+	ctx := context.Background()
+
+	unlock, err := cache.LockKey(ctx, "test_lock_key")
+	if err != nil {
+		t.Fatalf("LockKey failed: %v", err)
+	}
+
+	// 模拟锁过期后被其他调用方获取，原持有者释放时不应删除对方的锁
+	if err := cache.client.Set(ctx, "lock:test_lock_key", "other", time.Minute).Err(); err != nil {
+		t.Fatalf("Set lock failed: %v", err)
+	}
+	unlock()
+	if n, _ := cache.client.Exists(ctx, "lock:test_lock_key").Result(); n != 1 {
+		t.Error("Expected lock held by another owner to be kept")
+	}
+	cache.client.Del(ctx, "lock:test_lock_key")
+
+	unlock, err = cache.LockKey(ctx, "test_lock_key")
+	if err != nil {
+		t.Fatalf("LockKey failed: %v", err)
+	}
+	unlock()
+	if n, _ := cache.client.Exists(ctx, "lock:test_lock_key").Result(); n != 0 {
+		t.Error("Expected lock to be released")
+	}
+}
+
+func TestRedisCacheMSetWithTags(t *testing.T) {
+	if !checkRedisConnection() {
+		t.Skip("Redis server is not available")
+	}
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cacheConfig := &RedisCacheConfig{
+		Addr:     "localhost:6379",
+		Password: "",
+		DB:       0,
+	}
+	cache := NewRedisCache(config, cacheConfig)
+
+	ctx := context.Background()
+	cache.MDelete(ctx, []string{"mset_tags:a", "mset_tags:b"})
+	cache.DeleteByTag(ctx, "mset_old")
+	cache.DeleteByTag(ctx, "mset_new")
+	cache.ResetStats(ctx)
+
+	if err := cache.SetWithTags(ctx, "mset_tags:a", "a", []string{"mset_old"}, time.Minute); err != nil {
+		t.Fatalf("SetWithTags failed: %v", err)
+	}
+
+	// 覆盖已有的键时应移除其原有标签，且只统计新增的键
+	if err := cache.MSetWithTags(ctx, map[string]TaggedValue{
+		"mset_tags:a": {Value: "a2", Tags: []string{"mset_new"}},
+		"mset_tags:b": {Value: "b", Tags: []string{"mset_new"}},
+	}, time.Minute); err != nil {
+		t.Fatalf("MSetWithTags failed: %v", err)
+	}
+
+	keys, err := cache.GetByTag(ctx, "mset_old")
+	if err != nil {
+		t.Fatalf("GetByTag failed: %v", err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("Expected no keys for old tag, got %v", keys)
+	}
+	keys, err = cache.GetByTag(ctx, "mset_new")
+	if err != nil {
+		t.Fatalf("GetByTag failed: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("Expected 2 keys for new tag, got %v", keys)
+	}
+
+	stats, err := cache.GetStats(ctx)
+	if err != nil {
+		t.Fatalf("GetStats failed: %v", err)
+	}
+	if stats.KeyCount != 2 {
+		t.Errorf("Expected KeyCount 2, got %v", stats.KeyCount)
+	}
+}
@@ -10,11 +10,19 @@ type BaseConfig struct {
 	CleanupInterval time.Duration `yaml:"cleanup_interval"`
 	// MaxSize 最大缓存条目数
 	MaxSize int `yaml:"max_size"`
+	// SlidingTTL 是否启用滑动过期：命中时将过期时间重置为 DefaultExpiration
+	SlidingTTL bool `yaml:"sliding_ttl"`
+	// KeyVersion 缓存键版本，非空时所有缓存键会添加版本前缀，修改版本即可使旧缓存失效
+	KeyVersion string `yaml:"key_version"`
+	// ReadOnly 只读模式，开启后写操作被忽略，读操作正常执行，用于故障处理时临时停止写入缓存
+	ReadOnly bool `yaml:"read_only"`
+	// Serializer 文件、Redis 和 Memcached 缓存的值序列化器：json（默认）、gob 或通过 RegisterSerializer 注册的名称
+	Serializer string `yaml:"serializer"`
 }
 
 // Config 缓存配置
 type Config struct {
-	// Type 缓存类型：memory, redis, file
+	// Type 缓存类型：memory, redis, file, bolt, badger（bolt 的别名）, memcached
 	Type string `yaml:"type"`
 	// BaseConfig 基础配置
 	BaseConfig BaseConfig `yaml:",inline"`
@@ -22,6 +30,14 @@ type Config struct {
 	RedisConfig RedisCacheConfig `yaml:"redis_config"`
 	// FileConfig 文件缓存配置
 	FileConfig FileCacheConfig `yaml:"file_config"`
+	// BoltConfig 嵌入式 bbolt 缓存配置
+	BoltConfig BoltCacheConfig `yaml:"bolt_config"`
+	// BadgerConfig 缓存类型为 badger 时使用的嵌入式缓存配置
+	BadgerConfig BadgerConfig `yaml:"badger_config"`
+	// MemcachedConfig Memcached 缓存配置
+	MemcachedConfig MemcachedCacheConfig `yaml:"memcached_config"`
 	// MemoryConfig
 	MemoryConfig MemoryCacheConfig `yaml:"memory_config"`
+	// RefreshAhead 提前刷新配置，配合 WithRefreshAhead 使用
+	RefreshAhead RefreshAheadConfig `yaml:"refresh_ahead"`
 }
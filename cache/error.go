@@ -1,6 +1,9 @@
 package cache
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrNotImplemented 未实现错误
@@ -11,4 +14,10 @@ var (
 	ErrNotFound = errors.New("cache not found")
 	// ErrInvalidValue 无效的值
 	ErrInvalidValue = errors.New("invalid value")
+	// ErrNotInteger 计数器操作的缓存值不是整数，同时匹配 ErrInvalidValue
+	ErrNotInteger = fmt.Errorf("%w: value is not an integer", ErrInvalidValue)
+	// ErrMarshal 缓存值无法序列化
+	ErrMarshal = errors.New("failed to marshal value")
+	// ErrReadOnly 只读模式下无法执行写操作
+	ErrReadOnly = errors.New("cache is read-only")
 )
@@ -0,0 +1,214 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// FallbackCache 故障转移缓存
+// 主缓存出现连接类错误（非未命中）时，透明地改用备用缓存
+type FallbackCache struct {
+	primary   ICache
+	secondary ICache
+	// MirrorWrites 是否将写操作同时写入备用缓存
+	MirrorWrites bool
+	degraded     atomic.Bool
+}
+
+// NewFallback 创建故障转移缓存
+func NewFallback(primary, secondary ICache) *FallbackCache {
+	return &FallbackCache{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// Set 设置缓存
+func (c *FallbackCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	err := c.primary.Set(ctx, key, value, ttl)
+	if c.shouldFallback(ctx, "Set", err) {
+		return c.secondary.Set(ctx, key, value, ttl)
+	}
+	if c.MirrorWrites {
+		c.mirror("Set", c.secondary.Set(ctx, key, value, ttl))
+	}
+	return err
+}
+
+// Get 获取缓存
+func (c *FallbackCache) Get(ctx context.Context, key string, value interface{}) error {
+	err := c.primary.Get(ctx, key, value)
+	if c.shouldFallback(ctx, "Get", err) {
+		return c.secondary.Get(ctx, key, value)
+	}
+	return err
+}
+
+// Delete 删除缓存
+func (c *FallbackCache) Delete(ctx context.Context, key string) error {
+	err := c.primary.Delete(ctx, key)
+	if c.shouldFallback(ctx, "Delete", err) {
+		return c.secondary.Delete(ctx, key)
+	}
+	if c.MirrorWrites {
+		c.mirror("Delete", c.secondary.Delete(ctx, key))
+	}
+	return err
+}
+
+// Has 检查缓存是否存在
+func (c *FallbackCache) Has(ctx context.Context, key string) (bool, error) {
+	exists, err := c.primary.Has(ctx, key)
+	if c.shouldFallback(ctx, "Has", err) {
+		return c.secondary.Has(ctx, key)
+	}
+	return exists, err
+}
+
+// Clear 清空所有缓存
+func (c *FallbackCache) Clear(ctx context.Context) error {
+	err := c.primary.Clear(ctx)
+	if c.shouldFallback(ctx, "Clear", err) {
+		return c.secondary.Clear(ctx)
+	}
+	if c.MirrorWrites {
+		c.mirror("Clear", c.secondary.Clear(ctx))
+	}
+	return err
+}
+
+// GetStats 获取缓存统计信息
+func (c *FallbackCache) GetStats(ctx context.Context) (*Stats, error) {
+	stats, err := c.primary.GetStats(ctx)
+	if c.shouldFallback(ctx, "GetStats", err) {
+		return c.secondary.GetStats(ctx)
+	}
+	return stats, err
+}
+
+// HealthCheck 执行健康检查
+func (c *FallbackCache) HealthCheck(ctx context.Context) (*Health, error) {
+	health, err := c.primary.HealthCheck(ctx)
+	if c.shouldFallback(ctx, "HealthCheck", err) {
+		return c.secondary.HealthCheck(ctx)
+	}
+	if health != nil {
+		if health.Details == nil {
+			health.Details = make(map[string]interface{})
+		}
+		health.Details["degraded"] = c.degraded.Load()
+	}
+	return health, err
+}
+
+// MSet 批量设置缓存
+func (c *FallbackCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	err := c.primary.MSet(ctx, items, ttl)
+	if c.shouldFallback(ctx, "MSet", err) {
+		return c.secondary.MSet(ctx, items, ttl)
+	}
+	if c.MirrorWrites {
+		c.mirror("MSet", c.secondary.MSet(ctx, items, ttl))
+	}
+	return err
+}
+
+// MGet 批量获取缓存
+func (c *FallbackCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result, err := c.primary.MGet(ctx, keys)
+	if c.shouldFallback(ctx, "MGet", err) {
+		return c.secondary.MGet(ctx, keys)
+	}
+	return result, err
+}
+
+// MDelete 批量删除缓存
+func (c *FallbackCache) MDelete(ctx context.Context, keys []string) error {
+	err := c.primary.MDelete(ctx, keys)
+	if c.shouldFallback(ctx, "MDelete", err) {
+		return c.secondary.MDelete(ctx, keys)
+	}
+	if c.MirrorWrites {
+		c.mirror("MDelete", c.secondary.MDelete(ctx, keys))
+	}
+	return err
+}
+
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+// 计数器不做镜像写入，避免两侧计数重复累加
+func (c *FallbackCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := c.primary.IncrBy(ctx, key, delta, ttl)
+	if c.shouldFallback(ctx, "IncrBy", err) {
+		return c.secondary.IncrBy(ctx, key, delta, ttl)
+	}
+	return value, err
+}
+
+// shouldFallback 判断是否需要改用备用缓存，并记录降级与恢复
+// 调用方已取消或超时时不改用备用缓存，也不改变降级状态
+func (c *FallbackCache) shouldFallback(ctx context.Context, op string, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if !isUnavailable(err) {
+		if c.degraded.CompareAndSwap(true, false) {
+			logger.WithField("op", op).Info("cache primary recovered")
+		}
+		return false
+	}
+
+	if c.degraded.CompareAndSwap(false, true) {
+		logger.WithFields(map[string]interface{}{
+			"op":    op,
+			"error": err.Error(),
+		}).Warn("cache primary unavailable, falling back to secondary")
+	}
+	return true
+}
+
+// mirror 记录镜像写入备用缓存时的错误
+func (c *FallbackCache) mirror(op string, err error) {
+	if err != nil {
+		logger.WithFields(map[string]interface{}{
+			"op":    op,
+			"error": err.Error(),
+		}).Warn("cache mirror write to secondary failed")
+	}
+}
+
+// unavailableExcluded 与缓存是否可用无关的错误：未命中、值错误（含非整数）、序列化错误以及上下文取消或超时
+var unavailableExcluded = []error{
+	ErrNotFound,
+	ErrInvalidValue,
+	ErrNotInteger,
+	ErrMarshal,
+	context.Canceled,
+	context.DeadlineExceeded,
+}
+
+// isUnavailable 判断错误是否表示缓存不可用
+func isUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, target := range unavailableExcluded {
+		if errors.Is(err, target) {
+			return false
+		}
+	}
+	return true
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间
+func (c *FallbackCache) DefaultExpiration() time.Duration {
+	return defaultExpiration(c.primary)
+}
+
+// CacheKey 返回键在底层缓存中的键名
+func (c *FallbackCache) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, c.primary, key)
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// unavailableCache 模拟不可用的缓存
+type unavailableCache struct {
+	ICache
+}
+
+var errUnavailable = errors.New("connection refused")
+
+func (c *unavailableCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return errUnavailable
+}
+
+func (c *unavailableCache) Get(ctx context.Context, key string, value interface{}) error {
+	return errUnavailable
+}
+
+func TestFallbackCache(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	primary := NewMemoryCache(config, &MemoryCacheConfig{})
+	secondary := NewMemoryCache(config, &MemoryCacheConfig{})
+	cache := NewFallback(primary, secondary)
+	ctx := context.Background()
+
+	// 主缓存可用时，未命中不会回退到备用缓存
+	if err := secondary.Set(ctx, "key", "secondary", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	var result string
+	if err := cache.Get(ctx, "key", &result); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound from primary, got %v", err)
+	}
+
+	// 主缓存不可用时，读写使用备用缓存
+	cache = NewFallback(&unavailableCache{ICache: primary}, secondary)
+	if err := cache.Get(ctx, "key", &result); err != nil {
+		t.Fatalf("Expected fallback Get to succeed, got %v", err)
+	}
+	if result != "secondary" {
+		t.Errorf("Expected secondary, got %v", result)
+	}
+	if err := cache.Set(ctx, "other", "value", time.Minute); err != nil {
+		t.Fatalf("Expected fallback Set to succeed, got %v", err)
+	}
+	if exists, _ := secondary.Has(ctx, "other"); !exists {
+		t.Error("Expected fallback Set to write to secondary")
+	}
+}
+
+// failingCache 读写都返回指定错误的缓存
+type failingCache struct {
+	ICache
+	err error
+}
+
+func (c *failingCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.err
+}
+
+func (c *failingCache) Get(ctx context.Context, key string, value interface{}) error {
+	return c.err
+}
+
+func TestFallbackCacheIgnoresNonOutageErrors(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	primary := NewMemoryCache(config, &MemoryCacheConfig{})
+	secondary := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	// 非整数值的计数器操作不会改为递增备用缓存
+	cache := NewFallback(primary, secondary)
+	if err := primary.Set(ctx, "counter", "abc", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := cache.IncrBy(ctx, "counter", 1, time.Minute); !errors.Is(err, ErrNotInteger) {
+		t.Errorf("Expected ErrNotInteger, got %v", err)
+	}
+	if exists, _ := secondary.Has(ctx, "counter"); exists {
+		t.Error("Expected secondary counter to be untouched")
+	}
+
+	for _, err := range []error{
+		context.Canceled,
+		context.DeadlineExceeded,
+		fmt.Errorf("%w: json: unsupported type", ErrMarshal),
+	} {
+		cache := NewFallback(&failingCache{ICache: primary, err: err}, secondary)
+		if got := cache.Set(ctx, "key", "value", time.Minute); !errors.Is(got, err) {
+			t.Errorf("Expected %v from primary, got %v", err, got)
+		}
+		if cache.degraded.Load() {
+			t.Errorf("Expected %v not to mark the primary as degraded", err)
+		}
+	}
+
+	// 调用方取消请求时即使出现连接错误也不降级
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	cache = NewFallback(&unavailableCache{ICache: primary}, secondary)
+	if err := cache.Set(cancelled, "key", "value", time.Minute); err != errUnavailable {
+		t.Errorf("Expected primary error for cancelled request, got %v", err)
+	}
+	if cache.degraded.Load() {
+		t.Error("Expected cancelled request not to mark the primary as degraded")
+	}
+}
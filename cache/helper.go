@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// loadGroup 合并 GetOrSet 中对同一缓存键的并发加载
+var loadGroup singleflight.Group
+
+var (
+	// inFlightLoads 正在执行的 GetOrSet 加载数量
+	inFlightLoads atomic.Int64
+	// dedupedLoads 等待并共享其他调用加载结果、未调用 loader 的 GetOrSet 调用次数
+	dedupedLoads atomic.Int64
+)
+
+// InFlight 返回 GetOrSet 中正在执行的加载数量，同一键的并发加载计为一个
+func InFlight() int {
+	return int(inFlightLoads.Load())
+}
+
+// DedupedLoads 返回 GetOrSet 因合并并发加载而未调用 loader 的累计调用次数
+func DedupedLoads() int64 {
+	return dedupedLoads.Load()
+}
+
+// GetTypedOrSet 获取指定类型的缓存值，未命中时调用 loader 加载并写入缓存，ttl 不大于0时使用缓存的默认过期时间
+// 命中时缓存值会被转换为 T，类型不一致时通过JSON转换（数字保留为 json.Number）
+func GetTypedOrSet[T any](ctx context.Context, cache ICache, key string, loader func(ctx context.Context) (T, error), ttl time.Duration) (T, error) {
+	var zero T
+
+	var raw interface{}
+	err := cache.Get(ctx, key, &raw)
+	if err == nil {
+		return convertTo[T](raw)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return zero, err
+	}
+
+	value, err := loader(ctx)
+	if err != nil {
+		return zero, err
+	}
+	if ttl <= 0 {
+		ttl = defaultExpiration(cache)
+	}
+	if err := cache.Set(ctx, key, value, ttl); err != nil {
+		return value, fmt.Errorf("failed to set cache: %v", err)
+	}
+	return value, nil
+}
+
+// GetOrSet 获取缓存值，未命中时调用 loader 加载并以 ttl 写入缓存后返回
+// ttl 不大于0时使用缓存的默认过期时间（经由各包装器转发）；loader 返回错误时不写入缓存并直接返回该错误
+// 同一缓存实例中同一个键（经 CacheKeyResolver 改写后的键）的并发未命中只会调用一次 loader，
+// 其余调用等待并共享其结果或错误，loader 使用第一个调用者的 ctx
+func GetOrSet(ctx context.Context, cache ICache, key string, ttl time.Duration, loader func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	var value interface{}
+	err := cache.Get(ctx, key, &value)
+	if err == nil {
+		return value, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	loaded := false
+	value, err, _ = loadGroup.Do(flightKey(ctx, cache, key), func() (interface{}, error) {
+		loaded = true
+		inFlightLoads.Add(1)
+		defer inFlightLoads.Add(-1)
+
+		value, err := loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl := ttl
+		if ttl <= 0 {
+			ttl = defaultExpiration(cache)
+		}
+		if err := cache.Set(ctx, key, value, ttl); err != nil {
+			return value, fmt.Errorf("failed to set cache: %v", err)
+		}
+		return value, nil
+	})
+	if !loaded {
+		dedupedLoads.Add(1)
+	}
+	return value, err
+}
+
+// defaultExpiration 返回缓存的默认过期时间，缓存未实现 DefaultExpirationProvider 时返回0
+func defaultExpiration(cache ICache) time.Duration {
+	if p, ok := cache.(DefaultExpirationProvider); ok {
+		return p.DefaultExpiration()
+	}
+	return 0
+}
+
+// resolveCacheKey 返回键在底层存储中的键名，缓存未实现 CacheKeyResolver 时返回原键
+func resolveCacheKey(ctx context.Context, cache ICache, key string) string {
+	if r, ok := cache.(CacheKeyResolver); ok {
+		return r.CacheKey(ctx, key)
+	}
+	return key
+}
+
+// flightKey 返回合并加载使用的键，包含缓存实例和改写后的键，避免不同缓存或命名空间的相同键互相合并
+func flightKey(ctx context.Context, cache ICache, key string) string {
+	return fmt.Sprintf("%p:%s", cache, resolveCacheKey(ctx, cache, key))
+}
+
+// convertTo 将缓存值转换为类型 T
+func convertTo[T any](raw interface{}) (T, error) {
+	var result T
+	if v, ok := raw.(T); ok {
+		return v, nil
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal cached value: %v", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&result); err != nil {
+		return result, fmt.Errorf("failed to convert cached value to %T: %v", result, err)
+	}
+	return result, nil
+}
+
+// MGetTyped 批量获取缓存并转换为指定类型，未命中的键不包含在结果中
+// 内存缓存直接返回保存的值，Redis、文件缓存的值经JSON解码后转换
+func MGetTyped[T any](ctx context.Context, cache ICache, keys []string) (map[string]T, error) {
+	values, err := cache.MGet(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]T, len(values))
+	for key, raw := range values {
+		value, err := convertTo[T](raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert cached value of %s: %v", key, err)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// Incr 将计数器加1并返回新值，计数器不存在时从0开始，过期时间使用默认过期时间
+func Incr(ctx context.Context, cache ICache, key string) (int64, error) {
+	return cache.IncrBy(ctx, key, 1, 0)
+}
+
+// Decr 将计数器减1并返回新值，计数器不存在时从0开始，过期时间使用默认过期时间
+func Decr(ctx context.Context, cache ICache, key string) (int64, error) {
+	return cache.IncrBy(ctx, key, -1, 0)
+}
+
+// DecrBy 将计数器减少 delta 并返回新值，计数器不存在时从0开始，过期时间使用默认过期时间
+// 缓存值不是整数时返回 ErrNotInteger
+func DecrBy(ctx context.Context, cache ICache, key string, delta int64) (int64, error) {
+	return cache.IncrBy(ctx, key, -delta, 0)
+}
@@ -0,0 +1,388 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type helperTestUser struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// newTestCaches 创建内存、文件和 bbolt 缓存，用于对多种实现执行同一组测试
+func newTestCaches(t *testing.T) map[string]ICache {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	bolt, err := NewBoltCache(config, &BoltCacheConfig{Path: filepath.Join(t.TempDir(), "cache.db")})
+	if err != nil {
+		t.Fatalf("NewBoltCache failed: %v", err)
+	}
+	t.Cleanup(func() { bolt.Close() })
+
+	return map[string]ICache{
+		"memory": NewMemoryCache(config, &MemoryCacheConfig{}),
+		"file":   NewFileCache(config, &FileCacheConfig{Directory: t.TempDir()}),
+		"bolt":   bolt,
+	}
+}
+
+func TestGetTypedOrSet(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	calls := 0
+	loader := func(ctx context.Context) (helperTestUser, error) {
+		calls++
+		return helperTestUser{ID: 1, Name: "alice"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		user, err := GetTypedOrSet(ctx, cache, "user:1", loader, time.Minute)
+		if err != nil {
+			t.Fatalf("GetTypedOrSet failed: %v", err)
+		}
+		if user.ID != 1 || user.Name != "alice" {
+			t.Errorf("Expected alice, got %+v", user)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once, got %d", calls)
+	}
+
+	// 缓存值类型与 T 不一致时通过JSON转换
+	if err := cache.Set(ctx, "user:2", map[string]interface{}{"id": 2, "name": "bob"}, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	user, err := GetTypedOrSet(ctx, cache, "user:2", loader, time.Minute)
+	if err != nil {
+		t.Fatalf("GetTypedOrSet failed: %v", err)
+	}
+	if user.ID != 2 || user.Name != "bob" {
+		t.Errorf("Expected bob, got %+v", user)
+	}
+
+	// 加载失败时返回错误
+	loadErr := errors.New("load failed")
+	_, err = GetTypedOrSet(ctx, cache, "user:3", func(ctx context.Context) (helperTestUser, error) {
+		return helperTestUser{}, loadErr
+	}, time.Minute)
+	if !errors.Is(err, loadErr) {
+		t.Errorf("Expected load error, got %v", err)
+	}
+}
+
+func TestGetTypedOrSetDefaultExpiration(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: time.Minute,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	calls := 0
+	loader := func(ctx context.Context) (helperTestUser, error) {
+		calls++
+		return helperTestUser{ID: 1, Name: "alice"}, nil
+	}
+
+	// ttl 为0时使用默认过期时间，第二次调用命中缓存
+	for i := 0; i < 2; i++ {
+		if _, err := GetTypedOrSet(ctx, cache, "user:1", loader, 0); err != nil {
+			t.Fatalf("GetTypedOrSet failed: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once, got %d", calls)
+	}
+}
+
+func TestGetOrSet(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: time.Minute,
+	}
+	cache := WithKeyVersion(NewMemoryCache(config, &MemoryCacheConfig{}), "v1")
+	ctx := context.Background()
+
+	calls := 0
+	loader := func(ctx context.Context) (interface{}, error) {
+		calls++
+		return "alice", nil
+	}
+
+	// ttl 为0时使用默认过期时间
+	for i := 0; i < 2; i++ {
+		value, err := GetOrSet(ctx, cache, "user:1", 0, loader)
+		if err != nil {
+			t.Fatalf("GetOrSet failed: %v", err)
+		}
+		if value != "alice" {
+			t.Errorf("Expected alice, got %v", value)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once, got %d", calls)
+	}
+
+	// 加载失败时不写入缓存
+	loadErr := errors.New("load failed")
+	_, err := GetOrSet(ctx, cache, "user:2", time.Minute, func(ctx context.Context) (interface{}, error) {
+		return nil, loadErr
+	})
+	if !errors.Is(err, loadErr) {
+		t.Errorf("Expected load error, got %v", err)
+	}
+	if exists, _ := cache.Has(ctx, "user:2"); exists {
+		t.Error("Expected nothing cached after load error")
+	}
+}
+
+func TestGetOrSetThroughWrappers(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: time.Minute,
+	}
+	newMemory := func() ICache {
+		return NewMemoryCache(config, &MemoryCacheConfig{})
+	}
+	refresh := WithRefreshAhead(newMemory(), func(ctx context.Context, key string) (interface{}, error) {
+		return nil, ErrNotFound
+	}, RefreshAheadConfig{})
+	defer refresh.Close()
+
+	caches := map[string]ICache{
+		"slowlog":    WithSlowLog(newMemory(), time.Second),
+		"replicator": NewReplicator(newMemory(), newMemory(), false),
+		"fallback":   NewFallback(newMemory(), newMemory()),
+		"negative":   WithNegativeCache(newMemory(), time.Minute),
+		"refresh":    refresh,
+	}
+
+	ctx := context.Background()
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			for i := 0; i < 3; i++ {
+				_, err := GetOrSet(ctx, cache, "user:1", 0, func(ctx context.Context) (interface{}, error) {
+					calls++
+					return "alice", nil
+				})
+				if err != nil {
+					t.Fatalf("GetOrSet failed: %v", err)
+				}
+			}
+			if calls != 1 {
+				t.Errorf("Expected loader to be called once, got %d", calls)
+			}
+		})
+	}
+
+	if got := defaultExpiration(WithReadOnly(newMemory())); got != time.Minute {
+		t.Errorf("Expected read-only wrapper to forward default expiration, got %v", got)
+	}
+}
+
+func TestGetOrSetSingleflight(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: time.Minute,
+	}
+	cache := WithKeyFromContext(NewMemoryCache(config, &MemoryCacheConfig{}), ContextKeyPrefix(tenantKey{}))
+
+	checkNamespaceFlights(t, cache)
+
+	// 键改写包装在其他包装器内部时同样按命名空间区分
+	checkNamespaceFlights(t, WithSlowLog(WithKeyFromContext(NewMemoryCache(config, &MemoryCacheConfig{}), ContextKeyPrefix(tenantKey{})), time.Second))
+
+	// 共享的错误返回给所有等待者且不写入缓存
+	loadErr := errors.New("load failed")
+	block := make(chan struct{})
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		go func() {
+			_, err := GetOrSet(context.Background(), cache, "broken", time.Minute, func(ctx context.Context) (interface{}, error) {
+				<-block
+				return nil, loadErr
+			})
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(block)
+	for i := 0; i < 5; i++ {
+		if err := <-errs; !errors.Is(err, loadErr) {
+			t.Errorf("Expected shared load error, got %v", err)
+		}
+	}
+	if exists, _ := cache.Has(context.Background(), "broken"); exists {
+		t.Error("Expected nothing cached after load error")
+	}
+}
+
+func TestGetOrSetInFlight(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: time.Minute,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	deduped := DedupedLoads()
+	var calls atomic.Int32
+	block := make(chan struct{})
+	loader := func(ctx context.Context) (interface{}, error) {
+		calls.Add(1)
+		<-block
+		return "value", nil
+	}
+
+	const callers = 5
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := GetOrSet(ctx, cache, "key", time.Minute, loader); err != nil {
+				t.Errorf("GetOrSet failed: %v", err)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := InFlight(); n != 1 {
+		t.Errorf("Expected 1 load in flight, got %d", n)
+	}
+	close(block)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected loader to be called once, got %d", n)
+	}
+	if n := InFlight(); n != 0 {
+		t.Errorf("Expected no load in flight, got %d", n)
+	}
+	if n := DedupedLoads() - deduped; n != callers-1 {
+		t.Errorf("Expected %d deduplicated loads, got %d", callers-1, n)
+	}
+}
+
+func TestIncrDecr(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: time.Minute,
+	}
+	cache := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	// 首次调用时计数器从0开始
+	if n, err := Incr(ctx, cache, "visits"); err != nil || n != 1 {
+		t.Fatalf("Expected 1, got %d (%v)", n, err)
+	}
+	if n, err := Incr(ctx, cache, "visits"); err != nil || n != 2 {
+		t.Fatalf("Expected 2, got %d (%v)", n, err)
+	}
+	if n, err := Decr(ctx, cache, "visits"); err != nil || n != 1 {
+		t.Fatalf("Expected 1, got %d (%v)", n, err)
+	}
+	if n, err := Decr(ctx, cache, "stock"); err != nil || n != -1 {
+		t.Fatalf("Expected -1, got %d (%v)", n, err)
+	}
+
+	if n, err := DecrBy(ctx, cache, "stock", 4); err != nil || n != -5 {
+		t.Fatalf("Expected -5, got %d (%v)", n, err)
+	}
+
+	var visits int64
+	if err := cache.Get(ctx, "visits", &visits); err != nil || visits != 1 {
+		t.Errorf("Expected stored counter 1, got %d (%v)", visits, err)
+	}
+
+	// 非整数值返回 ErrNotInteger，同时匹配 ErrInvalidValue
+	if err := cache.Set(ctx, "name", "alice", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	_, err := Incr(ctx, cache, "name")
+	if !errors.Is(err, ErrNotInteger) || !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("Expected ErrNotInteger, got %v", err)
+	}
+}
+
+func TestMGetTyped(t *testing.T) {
+	caches := newTestCaches(t)
+	ctx := context.Background()
+
+	for name, cache := range caches {
+		users := map[string]interface{}{
+			"user:1": helperTestUser{ID: 1, Name: "alice"},
+			"user:2": helperTestUser{ID: 2, Name: "bob"},
+		}
+		if err := cache.MSet(ctx, users, time.Minute); err != nil {
+			t.Fatalf("%s: MSet failed: %v", name, err)
+		}
+
+		result, err := MGetTyped[helperTestUser](ctx, cache, []string{"user:1", "user:2", "user:3"})
+		if err != nil {
+			t.Fatalf("%s: MGetTyped failed: %v", name, err)
+		}
+		if len(result) != 2 {
+			t.Errorf("%s: expected misses to be omitted, got %v", name, result)
+		}
+		if result["user:1"] != (helperTestUser{ID: 1, Name: "alice"}) || result["user:2"] != (helperTestUser{ID: 2, Name: "bob"}) {
+			t.Errorf("%s: unexpected typed result: %v", name, result)
+		}
+	}
+}
+
+// checkNamespaceFlights 检查同一命名空间的并发未命中只调用一次 loader，不同命名空间互不合并
+func checkNamespaceFlights(t *testing.T, cache ICache) {
+	t.Helper()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(ctx context.Context) (interface{}, error) {
+		calls.Add(1)
+		<-release
+		return ctx.Value(tenantKey{}), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		tenant := []string{"a", "b"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.WithValue(context.Background(), tenantKey{}, tenant)
+			value, err := GetOrSet(ctx, cache, "config", 0, loader)
+			if err != nil {
+				t.Errorf("GetOrSet failed: %v", err)
+			}
+			if value != tenant {
+				t.Errorf("Expected value of tenant %s, got %v", tenant, value)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 2 {
+		t.Errorf("Expected loader to be called once per namespace, got %d", got)
+	}
+}
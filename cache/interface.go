@@ -27,6 +27,21 @@ type ICache interface {
 	MGet(ctx context.Context, keys []string) (map[string]interface{}, error)
 	// MDelete 批量删除缓存
 	MDelete(ctx context.Context, keys []string) error
+	// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+	// 键不存在时以 delta 创建并设置 ttl，已存在时保留原有的过期时间
+	IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// DefaultExpirationProvider 提供默认过期时间的缓存，GetOrSet 在未指定过期时间时使用
+type DefaultExpirationProvider interface {
+	// DefaultExpiration 返回默认过期时间，0 表示未设置
+	DefaultExpiration() time.Duration
+}
+
+// CacheKeyResolver 能够给出键在底层存储中实际键名的缓存，改写键的包装器需实现该接口，其他包装器需转发
+type CacheKeyResolver interface {
+	// CacheKey 返回 key 经过改写后在底层存储中的键名
+	CacheKey(ctx context.Context, key string) string
 }
 
 // Health 健康检查结果
@@ -35,3 +50,11 @@ type Health struct {
 	Details   map[string]interface{} `json:"details"`
 	Timestamp time.Time              `json:"timestamp"`
 }
+
+// TaggedValue 带标签的缓存值
+type TaggedValue struct {
+	// Value 缓存值
+	Value interface{}
+	// Tags 标签列表
+	Tags []string
+}
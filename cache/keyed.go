@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// KeyedCache 键改写缓存，访问底层缓存前通过 keyFunc 改写缓存键
+type KeyedCache struct {
+	inner   ICache
+	keyFunc func(ctx context.Context, key string) string
+}
+
+// newKeyedCache 创建键改写缓存
+func newKeyedCache(inner ICache, keyFunc func(ctx context.Context, key string) string) *KeyedCache {
+	return &KeyedCache{
+		inner:   inner,
+		keyFunc: keyFunc,
+	}
+}
+
+// WithKeyVersion 为所有缓存键添加版本前缀，如 v2:user:1
+// 修改版本后旧版本的缓存不再可见，并随过期时间自然淘汰，无需清空缓存
+func WithKeyVersion(inner ICache, version string) ICache {
+	if version == "" {
+		return inner
+	}
+	prefix := version + ":"
+	return newKeyedCache(inner, func(ctx context.Context, key string) string {
+		return prefix + key
+	})
+}
+
+// WithKeyFromContext 通过 keyFunc 根据上下文改写缓存键，如在键前添加租户ID，使不同租户的缓存互不冲突
+func WithKeyFromContext(inner ICache, keyFunc func(ctx context.Context, key string) string) ICache {
+	if keyFunc == nil {
+		return inner
+	}
+	return newKeyedCache(inner, keyFunc)
+}
+
+// ContextKeyPrefix 返回以上下文中 ctxKey 对应的值作为前缀的键改写函数，如 tenant-a:user:1
+// 上下文中不存在该值时保持原键不变
+func ContextKeyPrefix(ctxKey interface{}) func(ctx context.Context, key string) string {
+	return func(ctx context.Context, key string) string {
+		value := ctx.Value(ctxKey)
+		if value == nil {
+			return key
+		}
+		return fmt.Sprint(value) + ":" + key
+	}
+}
+
+// Set 设置缓存
+func (c *KeyedCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return c.inner.Set(ctx, c.keyFunc(ctx, key), value, ttl)
+}
+
+// Get 获取缓存
+func (c *KeyedCache) Get(ctx context.Context, key string, value interface{}) error {
+	return c.inner.Get(ctx, c.keyFunc(ctx, key), value)
+}
+
+// Delete 删除缓存
+func (c *KeyedCache) Delete(ctx context.Context, key string) error {
+	return c.inner.Delete(ctx, c.keyFunc(ctx, key))
+}
+
+// Has 检查缓存是否存在
+func (c *KeyedCache) Has(ctx context.Context, key string) (bool, error) {
+	return c.inner.Has(ctx, c.keyFunc(ctx, key))
+}
+
+// Clear 清空所有缓存，会清空底层缓存中的全部数据，不限于改写后的键
+func (c *KeyedCache) Clear(ctx context.Context) error {
+	return c.inner.Clear(ctx)
+}
+
+// CacheKey 返回改写后的键在底层缓存中的键名
+func (c *KeyedCache) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, c.inner, c.keyFunc(ctx, key))
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间
+func (c *KeyedCache) DefaultExpiration() time.Duration {
+	return defaultExpiration(c.inner)
+}
+
+// GetStats 获取缓存统计信息
+func (c *KeyedCache) GetStats(ctx context.Context) (*Stats, error) {
+	return c.inner.GetStats(ctx)
+}
+
+// HealthCheck 执行健康检查
+func (c *KeyedCache) HealthCheck(ctx context.Context) (*Health, error) {
+	return c.inner.HealthCheck(ctx)
+}
+
+// MSet 批量设置缓存
+func (c *KeyedCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	mapped := make(map[string]interface{}, len(items))
+	for key, value := range items {
+		mapped[c.keyFunc(ctx, key)] = value
+	}
+	return c.inner.MSet(ctx, mapped, ttl)
+}
+
+// MGet 批量获取缓存，返回结果使用原始的缓存键
+func (c *KeyedCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	mapped := make([]string, len(keys))
+	original := make(map[string]string, len(keys))
+	for i, key := range keys {
+		mapped[i] = c.keyFunc(ctx, key)
+		original[mapped[i]] = key
+	}
+
+	values, err := c.inner.MGet(ctx, mapped)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		result[original[key]] = value
+	}
+	return result, nil
+}
+
+// MDelete 批量删除缓存
+func (c *KeyedCache) MDelete(ctx context.Context, keys []string) error {
+	mapped := make([]string, len(keys))
+	for i, key := range keys {
+		mapped[i] = c.keyFunc(ctx, key)
+	}
+	return c.inner.MDelete(ctx, mapped)
+}
+
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+func (c *KeyedCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	return c.inner.IncrBy(ctx, c.keyFunc(ctx, key), delta, ttl)
+}
+
+// Txn 执行事务，底层缓存不支持事务时返回 ErrNotImplemented
+func (c *KeyedCache) Txn(ctx context.Context, fn func(tx Txn) error) error {
+	return RunTxn(ctx, c.inner, func(tx Txn) error {
+		return fn(&keyedTxn{tx: tx, key: func(key string) string {
+			return c.keyFunc(ctx, key)
+		}})
+	})
+}
+
+// keyedTxn 改写缓存键的事务
+type keyedTxn struct {
+	tx  Txn
+	key func(key string) string
+}
+
+// Set 设置缓存
+func (t *keyedTxn) Set(key string, value interface{}, ttl time.Duration) {
+	t.tx.Set(t.key(key), value, ttl)
+}
+
+// Delete 删除缓存
+func (t *keyedTxn) Delete(key string) {
+	t.tx.Delete(t.key(key))
+}
+
+// IncrBy 为整数缓存值增加 delta
+func (t *keyedTxn) IncrBy(key string, delta int64, ttl time.Duration) {
+	t.tx.IncrBy(t.key(key), delta, ttl)
+}
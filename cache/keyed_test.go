@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWithKeyVersion(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	inner := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	v1 := WithKeyVersion(inner, "v1")
+	if err := v1.Set(ctx, "user:1", "alice", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := v1.MSet(ctx, map[string]interface{}{"user:2": "bob"}, time.Minute); err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+
+	var name string
+	if err := v1.Get(ctx, "user:1", &name); err != nil || name != "alice" {
+		t.Fatalf("Expected alice, got %q (%v)", name, err)
+	}
+	if exists, _ := inner.Has(ctx, "v1:user:1"); !exists {
+		t.Error("Expected key to be stored with version prefix")
+	}
+	values, err := v1.MGet(ctx, []string{"user:1", "user:2"})
+	if err != nil || values["user:1"] != "alice" || values["user:2"] != "bob" {
+		t.Errorf("Expected MGet to return original keys, got %v (%v)", values, err)
+	}
+
+	// 修改版本后旧缓存不可见
+	v2 := WithKeyVersion(inner, "v2")
+	if err := v2.Get(ctx, "user:1", &name); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound after version bump, got %v", err)
+	}
+	if exists, _ := v2.Has(ctx, "user:2"); exists {
+		t.Error("Expected user:2 to be invisible after version bump")
+	}
+}
+
+type tenantKey struct{}
+
+func TestWithKeyFromContext(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	inner := NewMemoryCache(config, &MemoryCacheConfig{})
+	c := WithKeyFromContext(inner, ContextKeyPrefix(tenantKey{}))
+
+	ctxA := context.WithValue(context.Background(), tenantKey{}, "tenant-a")
+	ctxB := context.WithValue(context.Background(), tenantKey{}, "tenant-b")
+
+	if err := c.Set(ctxA, "user:1", "alice", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := c.Set(ctxB, "user:1", "bob", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	var name string
+	if err := c.Get(ctxA, "user:1", &name); err != nil || name != "alice" {
+		t.Errorf("Expected alice for tenant-a, got %q (%v)", name, err)
+	}
+	if err := c.Get(ctxB, "user:1", &name); err != nil || name != "bob" {
+		t.Errorf("Expected bob for tenant-b, got %q (%v)", name, err)
+	}
+	if exists, _ := inner.Has(context.Background(), "tenant-a:user:1"); !exists {
+		t.Error("Expected key to be stored with tenant prefix")
+	}
+
+	// 删除一个租户的键不影响另一个租户
+	if err := c.Delete(ctxA, "user:1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if exists, _ := c.Has(ctxB, "user:1"); !exists {
+		t.Error("Expected tenant-b key to survive tenant-a delete")
+	}
+}
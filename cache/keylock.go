@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// keyLockExpiration Redis 键锁的过期时间，持有者异常退出时锁在过期后自动释放
+	keyLockExpiration = 30 * time.Second
+	// keyLockRetryInterval Redis 键锁被占用时的重试间隔
+	keyLockRetryInterval = 10 * time.Millisecond
+)
+
+// KeyLocker 支持按键加锁的缓存
+type KeyLocker interface {
+	// LockKey 获取 key 的互斥锁，锁被占用时阻塞直到获取成功或 ctx 结束
+	// 用于串行化同一个键上的读取-修改-写入等复合操作，调用方必须调用返回的 unlock 释放锁
+	LockKey(ctx context.Context, key string) (unlock func(), err error)
+}
+
+// keyMutex 进程内按键互斥锁，零值可直接使用
+type keyMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+// keyLock 单个键的锁，refs 为持有和等待该锁的调用方数量，归零时从 keyMutex 中移除
+type keyLock struct {
+	ch   chan struct{}
+	refs int
+}
+
+// lock 获取 key 的锁
+func (m *keyMutex) lock(ctx context.Context, key string) (func(), error) {
+	m.mu.Lock()
+	if m.locks == nil {
+		m.locks = make(map[string]*keyLock)
+	}
+	l, ok := m.locks[key]
+	if !ok {
+		l = &keyLock{ch: make(chan struct{}, 1)}
+		m.locks[key] = l
+	}
+	l.refs++
+	m.mu.Unlock()
+
+	select {
+	case l.ch <- struct{}{}:
+	case <-ctx.Done():
+		m.release(key, l)
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-l.ch
+			m.release(key, l)
+		})
+	}, nil
+}
+
+// release 减少锁的引用计数
+func (m *keyMutex) release(key string, l *keyLock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l.refs--
+	if l.refs == 0 {
+		delete(m.locks, key)
+	}
+}
+
+// LockKey 获取进程内的键锁
+func (c *MemoryCache) LockKey(ctx context.Context, key string) (func(), error) {
+	return c.keyLocks.lock(ctx, key)
+}
+
+// LockKey 获取进程内的键锁，多个进程共享缓存目录时不保证互斥
+func (c *FileCache) LockKey(ctx context.Context, key string) (func(), error) {
+	return c.keyLocks.lock(ctx, key)
+}
+
+// LockKey 获取进程内的键锁，bbolt 数据库文件同一时间只能被一个进程打开
+func (c *BoltCache) LockKey(ctx context.Context, key string) (func(), error) {
+	return c.keyLocks.lock(ctx, key)
+}
+
+// unlockKeyScript 仅在锁的值仍为本次持有者的令牌时删除锁键
+var unlockKeyScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// LockKey 通过 Redis 分布式锁获取键锁，锁键为 lock:<key>
+// 锁在 keyLockExpiration 后自动过期，复合操作需在过期前完成；
+// 锁的值为随机令牌，释放时只删除仍由本次持有的锁，过期后被其他调用方获取的锁不会被误删
+func (c *RedisCache) LockKey(ctx context.Context, key string) (func(), error) {
+	lockKey := "lock:" + key
+	token := lockToken()
+
+	ticker := time.NewTicker(keyLockRetryInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := c.client.SetNX(ctx, lockKey, token, keyLockExpiration).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lock: %v", err)
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			unlockKeyScript.Run(context.Background(), c.client, []string{lockKey}, token)
+		})
+	}, nil
+}
+
+// lockToken 生成锁持有者的随机令牌
+func lockToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// 随机数生成失败时使用时间戳作为备选
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// LockKey 改写键后在底层缓存中加锁，底层缓存不支持时返回 ErrNotImplemented
+func (c *KeyedCache) LockKey(ctx context.Context, key string) (func(), error) {
+	locker, ok := c.inner.(KeyLocker)
+	if !ok {
+		return nil, ErrNotImplemented
+	}
+	return locker.LockKey(ctx, c.keyFunc(ctx, key))
+}
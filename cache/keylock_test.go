@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLockKey(t *testing.T) {
+	caches := newTestCaches(t)
+
+	ctx := context.Background()
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			locker := cache.(KeyLocker)
+			if err := cache.Set(ctx, "counter", 0, time.Minute); err != nil {
+				t.Fatalf("Set failed: %v", err)
+			}
+
+			const workers = 50
+			var wg sync.WaitGroup
+			errs := make(chan error, workers)
+			for i := 0; i < workers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					unlock, err := locker.LockKey(ctx, "counter")
+					if err != nil {
+						errs <- err
+						return
+					}
+					defer unlock()
+
+					var value interface{}
+					if err := cache.Get(ctx, "counter", &value); err != nil {
+						errs <- err
+						return
+					}
+					n, _ := toInt64(value)
+					if err := cache.Set(ctx, "counter", n+1, time.Minute); err != nil {
+						errs <- err
+					}
+				}()
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				t.Fatalf("locked update failed: %v", err)
+			}
+
+			var value interface{}
+			if err := cache.Get(ctx, "counter", &value); err != nil {
+				t.Fatalf("Get failed: %v", err)
+			}
+			if n, _ := toInt64(value); n != workers {
+				t.Errorf("Expected counter %d, got %v", workers, value)
+			}
+		})
+	}
+}
+
+func TestLockKeyContextCancel(t *testing.T) {
+	cache := NewMemoryCache(&BaseConfig{MaxSize: 100, CleanupInterval: 60}, &MemoryCacheConfig{})
+
+	unlock, err := cache.LockKey(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("LockKey failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := cache.LockKey(ctx, "key"); err != context.DeadlineExceeded {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	unlock()
+	unlock()
+	relock, err := cache.LockKey(context.Background(), "key")
+	if err != nil {
+		t.Fatalf("LockKey after unlock failed: %v", err)
+	}
+	relock()
+
+	if len(cache.keyLocks.locks) != 0 {
+		t.Errorf("Expected released locks to be removed, got %d", len(cache.keyLocks.locks))
+	}
+}
@@ -23,21 +23,59 @@ func GetInstance() ICache {
 	return instance
 }
 
-// LoadConfig 加载配置并切换缓存实例
+// LoadConfig 加载配置并切换缓存实例，创建失败时保留当前的缓存实例
 func LoadConfig(config *Config) error {
-	var err error
+	cache, err := newCacheFromConfig(config)
+	if err != nil {
+		return err
+	}
+
 	once = sync.Once{}
 	once.Do(func() {
-		switch config.Type {
-		case "memory":
-			instance = NewMemoryCache(&config.BaseConfig, &config.MemoryConfig)
-		case "redis":
-			instance = NewRedisCache(&config.BaseConfig, &config.RedisConfig)
-		case "file":
-			instance = NewFileCache(&config.BaseConfig, &config.FileConfig)
-		default:
-			err = ErrInvalidCacheType
-		}
+		instance = cache
 	})
-	return err
+	return nil
+}
+
+// newCacheFromConfig 根据配置创建缓存实例
+func newCacheFromConfig(config *Config) (ICache, error) {
+	if _, err := newSerializer(config.BaseConfig.Serializer); err != nil {
+		return nil, err
+	}
+
+	var cache ICache
+	switch config.Type {
+	case "memory":
+		cache = NewMemoryCache(&config.BaseConfig, &config.MemoryConfig)
+	case "redis":
+		cache = NewRedisCache(&config.BaseConfig, &config.RedisConfig)
+	case "file":
+		cache = NewFileCache(&config.BaseConfig, &config.FileConfig)
+	case "bolt":
+		boltCache, err := NewBoltCache(&config.BaseConfig, &config.BoltConfig)
+		if err != nil {
+			return nil, err
+		}
+		cache = boltCache
+	case "badger":
+		boltCache, err := NewBoltCache(&config.BaseConfig, &config.BadgerConfig)
+		if err != nil {
+			return nil, err
+		}
+		cache = boltCache
+	case "memcached":
+		memcachedCache, err := NewMemcachedCache(&config.BaseConfig, &config.MemcachedConfig)
+		if err != nil {
+			return nil, err
+		}
+		cache = memcachedCache
+	default:
+		return nil, ErrInvalidCacheType
+	}
+
+	cache = WithKeyVersion(cache, config.BaseConfig.KeyVersion)
+	if config.BaseConfig.ReadOnly {
+		cache = WithReadOnly(cache)
+	}
+	return cache, nil
 }
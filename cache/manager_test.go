@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadConfigKeepsInstanceOnError(t *testing.T) {
+	if err := LoadConfig(&Config{Type: "memory", BaseConfig: BaseConfig{MaxSize: 100, CleanupInterval: 60}}); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	current := GetInstance()
+
+	for _, config := range []*Config{
+		{Type: "bolt"},
+		{Type: "badger"},
+		{Type: "memcached"},
+		{Type: "unknown"},
+	} {
+		if err := LoadConfig(config); err == nil {
+			t.Errorf("Expected error for %s config", config.Type)
+		}
+		if GetInstance() != current {
+			t.Errorf("Expected failed %s config to keep the current instance", config.Type)
+		}
+	}
+
+	if _, err := GetInstance().Has(context.Background(), "key"); err != nil {
+		t.Errorf("Has failed: %v", err)
+	}
+}
+
+func TestLoadConfigBadger(t *testing.T) {
+	err := LoadConfig(&Config{
+		Type:         "badger",
+		BaseConfig:   BaseConfig{MaxSize: 100, CleanupInterval: 60},
+		BadgerConfig: BadgerConfig{Path: filepath.Join(t.TempDir(), "cache.db"), SyncWrites: true},
+	})
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	bolt, ok := GetInstance().(*BoltCache)
+	if !ok {
+		t.Fatalf("Expected *BoltCache, got %T", GetInstance())
+	}
+	defer bolt.Close()
+
+	ctx := context.Background()
+	if err := bolt.Set(ctx, "key", "value", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	var value string
+	if err := bolt.Get(ctx, "key", &value); err != nil || value != "value" {
+		t.Errorf("Expected value, got %q (%v)", value, err)
+	}
+}
@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// negativeKeyPrefix 不存在标记的键前缀
+const negativeKeyPrefix = "neg:"
+
+// NegativeCache 负缓存
+// 对确认不存在的数据写入带过期时间的标记，过期前的加载请求直接返回 ErrNotFound，避免反复查询数据源
+type NegativeCache struct {
+	ICache
+	negativeTTL time.Duration
+}
+
+// WithNegativeCache 为缓存添加负缓存能力，negativeTTL 为不存在标记的过期时间
+func WithNegativeCache(inner ICache, negativeTTL time.Duration) *NegativeCache {
+	return &NegativeCache{
+		ICache:      inner,
+		negativeTTL: negativeTTL,
+	}
+}
+
+// SetMissing 将键标记为不存在
+func (c *NegativeCache) SetMissing(ctx context.Context, key string) error {
+	if err := c.ICache.Delete(ctx, key); err != nil {
+		return err
+	}
+	return c.ICache.Set(ctx, negativeKeyPrefix+key, true, c.negativeTTL)
+}
+
+// IsMissing 检查键是否被标记为不存在
+func (c *NegativeCache) IsMissing(ctx context.Context, key string) (bool, error) {
+	return c.ICache.Has(ctx, negativeKeyPrefix+key)
+}
+
+// Set 设置缓存，同时清除不存在标记
+func (c *NegativeCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := c.ICache.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	return c.ICache.Delete(ctx, negativeKeyPrefix+key)
+}
+
+// Delete 删除缓存，同时清除不存在标记
+func (c *NegativeCache) Delete(ctx context.Context, key string) error {
+	return c.ICache.MDelete(ctx, []string{key, negativeKeyPrefix + key})
+}
+
+// MSet 批量设置缓存，同时清除不存在标记
+func (c *NegativeCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	if err := c.ICache.MSet(ctx, items, ttl); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, negativeKeyPrefix+key)
+	}
+	return c.ICache.MDelete(ctx, keys)
+}
+
+// GetOrLoad 获取缓存，未命中时调用 loader 加载并写入缓存
+// 键被标记为不存在时直接返回 ErrNotFound；loader 返回 ErrNotFound 时写入不存在标记
+func (c *NegativeCache) GetOrLoad(ctx context.Context, key string, value interface{}, loader func(ctx context.Context) (interface{}, error), ttl time.Duration) error {
+	err := c.ICache.Get(ctx, key, value)
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	missing, err := c.IsMissing(ctx, key)
+	if err != nil {
+		return err
+	}
+	if missing {
+		return ErrNotFound
+	}
+
+	loaded, err := loader(ctx)
+	if errors.Is(err, ErrNotFound) {
+		if err := c.SetMissing(ctx, key); err != nil {
+			return err
+		}
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := c.Set(ctx, key, loaded, ttl); err != nil {
+		return err
+	}
+	return assignValue(value, loaded)
+}
+
+// assignValue 将加载的值写入 value 指向的变量，类型不一致时通过JSON转换
+func assignValue(value interface{}, loaded interface{}) error {
+	ptr := reflect.ValueOf(value)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return ErrInvalidValue
+	}
+
+	src := reflect.ValueOf(loaded)
+	if src.IsValid() && src.Type().AssignableTo(ptr.Elem().Type()) {
+		ptr.Elem().Set(src)
+		return nil
+	}
+
+	data, err := json.Marshal(loaded)
+	if err != nil {
+		return fmt.Errorf("failed to marshal loaded value: %v", err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to convert loaded value: %v", err)
+	}
+	return nil
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间
+func (c *NegativeCache) DefaultExpiration() time.Duration {
+	return defaultExpiration(c.ICache)
+}
+
+// CacheKey 返回键在底层缓存中的键名
+func (c *NegativeCache) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, c.ICache, key)
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNegativeCache(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	cache := WithNegativeCache(NewMemoryCache(config, &MemoryCacheConfig{}), 100*time.Millisecond)
+	ctx := context.Background()
+
+	calls := 0
+	loader := func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, ErrNotFound
+	}
+
+	var value string
+	for i := 0; i < 3; i++ {
+		if err := cache.GetOrLoad(ctx, "user:404", &value, loader, time.Minute); err != ErrNotFound {
+			t.Fatalf("Expected ErrNotFound, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected loader to be called once while marked missing, got %d", calls)
+	}
+
+	// 标记过期后重新加载
+	time.Sleep(150 * time.Millisecond)
+	cache.GetOrLoad(ctx, "user:404", &value, loader, time.Minute)
+	if calls != 2 {
+		t.Errorf("Expected loader to be called again after negative TTL, got %d", calls)
+	}
+
+	// 写入后清除不存在标记
+	if err := cache.SetMissing(ctx, "user:1"); err != nil {
+		t.Fatalf("SetMissing failed: %v", err)
+	}
+	if err := cache.Set(ctx, "user:1", "alice", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if missing, _ := cache.IsMissing(ctx, "user:1"); missing {
+		t.Error("Expected Set to clear the missing marker")
+	}
+
+	// 加载成功时写入缓存并返回值
+	err := cache.GetOrLoad(ctx, "user:2", &value, func(ctx context.Context) (interface{}, error) {
+		return "bob", nil
+	}, time.Minute)
+	if err != nil || value != "bob" {
+		t.Errorf("Expected bob, got %q (%v)", value, err)
+	}
+}
@@ -0,0 +1,204 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	bolt "go.etcd.io/bbolt"
+)
+
+// redisScanCount 按模式删除时每次 SCAN 返回的键数量提示
+const redisScanCount = 500
+
+// PatternDeleter 支持按通配符删除的缓存
+type PatternDeleter interface {
+	// DeleteMatching 删除键匹配 pattern 的所有缓存，返回删除的数量
+	// pattern 使用 glob 语法：* 匹配任意字符序列，? 匹配单个字符，[abc] 匹配字符集合
+	DeleteMatching(ctx context.Context, pattern string) (int, error)
+}
+
+// validatePattern 检查通配符模式是否合法
+func validatePattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid key pattern %q: %v", pattern, err)
+	}
+	return nil
+}
+
+// matchKey 判断缓存键是否匹配通配符模式
+// 与 path.Match 不同，* 也匹配 /，与 Redis 的 MATCH 语义保持一致
+func matchKey(pattern, key string) bool {
+	matched, _ := path.Match(strings.ReplaceAll(pattern, "/", "\x00"), strings.ReplaceAll(key, "/", "\x00"))
+	return matched
+}
+
+// DeleteMatching 删除键匹配 pattern 的所有缓存，并清理标签关系
+func (c *MemoryCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	if err := validatePattern(pattern); err != nil {
+		return 0, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	deleted := 0
+	for key := range c.data {
+		if matchKey(pattern, key) {
+			c.deleteItem(key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteMatching 删除文件名匹配 pattern 的所有缓存文件，并清理标签关系
+func (c *FileCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	if err := validatePattern(pattern); err != nil {
+		return 0, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entries, err := os.ReadDir(c.directory)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cache directory: %v", err)
+	}
+
+	deleted := 0
+	for _, entry := range entries {
+		if entry.IsDir() || !matchKey(pattern, entry.Name()) {
+			continue
+		}
+		if err := c.deleteItem(entry.Name()); err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// DeleteMatching 通过 SCAN 查找匹配 pattern 的键并批量删除，同时从标签集合中移除这些键
+// SCAN 期间新写入的键可能不会被删除
+func (c *RedisCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	if err := validatePattern(pattern); err != nil {
+		return 0, err
+	}
+
+	var removed []string
+	iter := c.client.Scan(ctx, 0, pattern, redisScanCount).Iterator()
+	batch := make([]string, 0, redisScanCount)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := c.client.Del(ctx, batch...).Result()
+		if err != nil {
+			return fmt.Errorf("failed to delete matching caches: %v", err)
+		}
+		c.stats.DecrKeyCountBy(n)
+		for _, key := range batch {
+			c.notifyListeners(EventTypeDelete, key)
+		}
+		removed = append(removed, batch...)
+		batch = batch[:0]
+		return nil
+	}
+
+	for iter.Next(ctx) {
+		batch = append(batch, iter.Val())
+		if len(batch) >= redisScanCount {
+			if err := flush(); err != nil {
+				return len(removed), err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return len(removed), fmt.Errorf("failed to scan cache keys: %v", err)
+	}
+	if err := flush(); err != nil {
+		return len(removed), err
+	}
+
+	if err := c.removeTagMembers(ctx, removed); err != nil {
+		return len(removed), err
+	}
+	return len(removed), nil
+}
+
+// removeTagMembers 根据键的标签记录，从其所属的标签集合中移除已删除的键
+func (c *RedisCache) removeTagMembers(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	tags := make([]*redis.StringSliceCmd, len(keys))
+	if _, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			tags[i] = pipe.SMembers(ctx, tagIndexKey(key))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to get tag references: %v", err)
+	}
+
+	if _, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			for _, tag := range tags[i].Val() {
+				pipe.SRem(ctx, fmt.Sprintf("tag:%s", tag), key)
+			}
+			pipe.Del(ctx, tagIndexKey(key))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to remove tag references: %v", err)
+	}
+	return nil
+}
+
+// DeleteMatching 在同一个写事务中删除键匹配 pattern 的所有缓存
+func (c *BoltCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	if err := validatePattern(pattern); err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltBucket)
+		var keys []string
+		err := bucket.ForEach(func(k, v []byte) error {
+			if matchKey(pattern, string(k)) {
+				keys = append(keys, string(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, key := range keys {
+			if err := deleteBoltItem(bucket, key); err != nil {
+				return err
+			}
+		}
+		deleted = len(keys)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// DeleteMatching 改写模式后在底层缓存中按通配符删除，底层缓存不支持时返回 ErrNotImplemented
+func (c *KeyedCache) DeleteMatching(ctx context.Context, pattern string) (int, error) {
+	deleter, ok := c.inner.(PatternDeleter)
+	if !ok {
+		return 0, ErrNotImplemented
+	}
+	return deleter.DeleteMatching(ctx, c.keyFunc(ctx, pattern))
+}
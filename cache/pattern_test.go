@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestDeleteMatching(t *testing.T) {
+	caches := newTestCaches(t)
+
+	ctx := context.Background()
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"user:1", "user:2", "users", "order:1"} {
+				if err := cache.Set(ctx, key, key, time.Minute); err != nil {
+					t.Fatalf("Set failed: %v", err)
+				}
+			}
+
+			deleter := cache.(PatternDeleter)
+			deleted, err := deleter.DeleteMatching(ctx, "user:*")
+			if err != nil {
+				t.Fatalf("DeleteMatching failed: %v", err)
+			}
+			if deleted != 2 {
+				t.Errorf("Expected 2 keys deleted, got %d", deleted)
+			}
+
+			for key, want := range map[string]bool{"user:1": false, "user:2": false, "users": true, "order:1": true} {
+				if exists, _ := cache.Has(ctx, key); exists != want {
+					t.Errorf("Expected Has(%s) = %v, got %v", key, want, exists)
+				}
+			}
+
+			if _, err := deleter.DeleteMatching(ctx, "user:["); err == nil {
+				t.Error("Expected error for malformed pattern")
+			}
+		})
+	}
+}
+
+func TestDeleteMatchingTags(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	ctx := context.Background()
+
+	memory := NewMemoryCache(config, &MemoryCacheConfig{})
+	file := NewFileCache(config, &FileCacheConfig{Directory: t.TempDir()})
+	for name, cache := range map[string]interface {
+		ICache
+		PatternDeleter
+		SetWithTags(ctx context.Context, key string, value interface{}, tags []string, ttl time.Duration) error
+		GetByTag(ctx context.Context, tag string) ([]string, error)
+	}{"memory": memory, "file": file} {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range []string{"session:a", "session:b", "profile:a"} {
+				if err := cache.SetWithTags(ctx, key, key, []string{"user-a"}, time.Minute); err != nil {
+					t.Fatalf("SetWithTags failed: %v", err)
+				}
+			}
+
+			if _, err := cache.DeleteMatching(ctx, "session:*"); err != nil {
+				t.Fatalf("DeleteMatching failed: %v", err)
+			}
+
+			keys, err := cache.GetByTag(ctx, "user-a")
+			if err != nil {
+				t.Fatalf("GetByTag failed: %v", err)
+			}
+			sort.Strings(keys)
+			if len(keys) != 1 || keys[0] != "profile:a" {
+				t.Errorf("Expected only profile:a tagged, got %v", keys)
+			}
+		})
+	}
+}
+
+func TestKeyedCacheDeleteMatching(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	inner := NewMemoryCache(config, &MemoryCacheConfig{})
+	v1 := WithKeyVersion(inner, "v1")
+	v2 := WithKeyVersion(inner, "v2")
+	ctx := context.Background()
+
+	for _, cache := range []ICache{v1, v2} {
+		if err := cache.Set(ctx, "user:1", "alice", time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+
+	deleted, err := v1.(PatternDeleter).DeleteMatching(ctx, "user:*")
+	if err != nil {
+		t.Fatalf("DeleteMatching failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 key deleted, got %d", deleted)
+	}
+	if exists, _ := v2.Has(ctx, "user:1"); !exists {
+		t.Error("Expected other version to be untouched")
+	}
+}
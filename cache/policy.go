@@ -1,5 +1,7 @@
 package cache
 
+import "container/list"
+
 // Policy 缓存策略接口
 type Policy interface {
 	// Update 更新缓存项
@@ -9,39 +11,56 @@ type Policy interface {
 }
 
 // LRUPolicy LRU策略实现
+// 键按写入顺序保存在链表中，读取不调整链表而是原子地记录访问时间；
+// 驱逐时从表头开始，放入链表后被读取过的键移到表尾再给一次机会（CLOCK 近似），均摊复杂度为 O(1)
 type LRUPolicy struct {
-	keys []string
+	order    *list.List
+	elements map[string]*list.Element
+}
+
+// lruEntry 链表中的键及其放入链表的时间（UnixNano）
+type lruEntry struct {
+	key    string
+	placed int64
 }
 
 // NewLRUPolicy 创建LRU策略
 func NewLRUPolicy() *LRUPolicy {
 	return &LRUPolicy{
-		keys: make([]string, 0),
+		order:    list.New(),
+		elements: make(map[string]*list.Element),
 	}
 }
 
-// Update 更新缓存项
+// Update 更新缓存项，将键移到表尾
 func (p *LRUPolicy) Update(key string, item *memoryItem) {
-	// 移除旧的位置
-	for i, k := range p.keys {
-		if k == key {
-			p.keys = append(p.keys[:i], p.keys[i+1:]...)
-			break
-		}
+	placed := item.updated
+	if elem, ok := p.elements[key]; ok {
+		elem.Value.(*lruEntry).placed = placed
+		p.order.MoveToBack(elem)
+		return
 	}
-	// 添加到末尾
-	p.keys = append(p.keys, key)
+	p.elements[key] = p.order.PushBack(&lruEntry{key: key, placed: placed})
 }
 
-// Evict 驱逐一个缓存项
+// Evict 驱逐最近最少使用的缓存项，已被删除的键直接移除并返回
 func (p *LRUPolicy) Evict(data map[string]*memoryItem) string {
-	if len(p.keys) == 0 {
-		return ""
+	for elem := p.order.Front(); elem != nil; elem = p.order.Front() {
+		entry := elem.Value.(*lruEntry)
+		item, exists := data[entry.key]
+		if exists {
+			if accessed := item.accessed.Load(); accessed > entry.placed {
+				entry.placed = accessed
+				p.order.MoveToBack(elem)
+				continue
+			}
+		}
+
+		p.order.Remove(elem)
+		delete(p.elements, entry.key)
+		return entry.key
 	}
-	// 移除最旧的项
-	key := p.keys[0]
-	p.keys = p.keys[1:]
-	return key
+	return ""
 }
 
 // FIFOPolicy FIFO策略实现
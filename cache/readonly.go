@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// ReadOnlyCache 只读缓存
+// 写操作直接返回 nil 而不修改底层缓存，读操作正常执行
+type ReadOnlyCache struct {
+	ICache
+}
+
+// WithReadOnly 将缓存包装为只读模式
+func WithReadOnly(inner ICache) *ReadOnlyCache {
+	return &ReadOnlyCache{ICache: inner}
+}
+
+// Set 忽略设置操作
+func (c *ReadOnlyCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	c.suppress("Set", key)
+	return nil
+}
+
+// Delete 忽略删除操作
+func (c *ReadOnlyCache) Delete(ctx context.Context, key string) error {
+	c.suppress("Delete", key)
+	return nil
+}
+
+// Clear 忽略清空操作
+func (c *ReadOnlyCache) Clear(ctx context.Context) error {
+	c.suppress("Clear", "")
+	return nil
+}
+
+// MSet 忽略批量设置操作
+func (c *ReadOnlyCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	c.suppress("MSet", "")
+	return nil
+}
+
+// MDelete 忽略批量删除操作
+func (c *ReadOnlyCache) MDelete(ctx context.Context, keys []string) error {
+	c.suppress("MDelete", "")
+	return nil
+}
+
+// IncrBy 计数器无法在不写入的情况下返回新值，只读模式下返回 ErrReadOnly
+func (c *ReadOnlyCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	c.suppress("IncrBy", key)
+	return 0, ErrReadOnly
+}
+
+// suppress 记录被忽略的写操作
+func (c *ReadOnlyCache) suppress(op, key string) {
+	logger.WithFields(map[string]interface{}{
+		"op":  op,
+		"key": key,
+	}).Debug("cache write suppressed in read-only mode")
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间
+func (c *ReadOnlyCache) DefaultExpiration() time.Duration {
+	return defaultExpiration(c.ICache)
+}
+
+// CacheKey 返回键在底层缓存中的键名
+func (c *ReadOnlyCache) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, c.ICache, key)
+}
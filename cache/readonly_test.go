@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReadOnlyCache(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	inner := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	if err := inner.Set(ctx, "existing", "value", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	c := WithReadOnly(inner)
+	if err := c.Set(ctx, "new", "value", time.Minute); err != nil {
+		t.Fatalf("Expected suppressed Set to return nil, got %v", err)
+	}
+	if exists, _ := inner.Has(ctx, "new"); exists {
+		t.Error("Expected Set to be suppressed in read-only mode")
+	}
+	if err := c.Delete(ctx, "existing"); err != nil {
+		t.Fatalf("Expected suppressed Delete to return nil, got %v", err)
+	}
+
+	var value string
+	if err := c.Get(ctx, "existing", &value); err != nil || value != "value" {
+		t.Errorf("Expected reads to pass through, got %q (%v)", value, err)
+	}
+	if _, err := c.IncrBy(ctx, "counter", 1, 0); err != ErrReadOnly {
+		t.Errorf("Expected ErrReadOnly from IncrBy, got %v", err)
+	}
+}
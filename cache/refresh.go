@@ -0,0 +1,258 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// RefreshAheadConfig 提前刷新配置
+type RefreshAheadConfig struct {
+	// Threshold 剩余过期时间低于该值时刷新
+	Threshold time.Duration `yaml:"threshold"`
+	// Interval 检查间隔
+	Interval time.Duration `yaml:"interval"`
+	// AccessWindow 最近访问窗口，只有在该时间内被读取过的热点键才会刷新
+	AccessWindow time.Duration `yaml:"access_window"`
+	// TTL 刷新后的过期时间，0 表示沿用写入时的过期时间
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// RefreshLoader 从数据源重新加载键对应的值
+type RefreshLoader func(ctx context.Context, key string) (interface{}, error)
+
+// refreshEntry 提前刷新跟踪的键信息
+type refreshEntry struct {
+	ttl        time.Duration
+	expiresAt  time.Time
+	lastAccess time.Time
+}
+
+// RefreshAheadCache 提前刷新缓存
+// 后台定时检查即将过期且最近被访问过的热点键，通过 loader 重新加载，使热点键始终保持有效；
+// 冷数据不刷新，按原过期时间淘汰。只跟踪通过该缓存写入的键
+type RefreshAheadCache struct {
+	ICache
+	loader  RefreshLoader
+	config  RefreshAheadConfig
+	entries map[string]*refreshEntry
+	mu      sync.Mutex
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// WithRefreshAhead 为缓存添加提前刷新能力，使用完毕后需调用 Close 停止后台刷新
+func WithRefreshAhead(inner ICache, loader RefreshLoader, config RefreshAheadConfig) *RefreshAheadCache {
+	if config.Interval <= 0 {
+		config.Interval = time.Second
+	}
+	if config.AccessWindow <= 0 {
+		config.AccessWindow = time.Minute
+	}
+
+	c := &RefreshAheadCache{
+		ICache:  inner,
+		loader:  loader,
+		config:  config,
+		entries: make(map[string]*refreshEntry),
+		done:    make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.run()
+	return c
+}
+
+// run 定时刷新即将过期的热点键
+func (c *RefreshAheadCache) run() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.refresh()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// refresh 检查并刷新即将过期的热点键，已过期的键不再跟踪
+func (c *RefreshAheadCache) refresh() {
+	now := time.Now()
+	var keys []string
+
+	c.mu.Lock()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if entry.expiresAt.Sub(now) < c.config.Threshold && now.Sub(entry.lastAccess) < c.config.AccessWindow {
+			keys = append(keys, key)
+		}
+	}
+	c.mu.Unlock()
+
+	ctx := context.Background()
+	for _, key := range keys {
+		value, err := c.loader(ctx, key)
+		if err != nil {
+			logger.WithFields(map[string]interface{}{
+				"key":   key,
+				"error": err.Error(),
+			}).Warn("cache refresh-ahead load failed")
+			continue
+		}
+
+		c.mu.Lock()
+		entry, exists := c.entries[key]
+		var entryTTL time.Duration
+		if exists {
+			entryTTL = entry.ttl
+		}
+		c.mu.Unlock()
+		if !exists {
+			continue
+		}
+
+		ttl := c.config.TTL
+		if ttl <= 0 {
+			ttl = entryTTL
+		}
+		if err := c.ICache.Set(ctx, key, value, ttl); err != nil {
+			logger.WithFields(map[string]interface{}{
+				"key":   key,
+				"error": err.Error(),
+			}).Warn("cache refresh-ahead set failed")
+			continue
+		}
+		c.track(key, ttl)
+	}
+}
+
+// track 记录键的过期时间，ttl 不大于0时使用底层缓存的默认过期时间，永不过期的键无需跟踪
+func (c *RefreshAheadCache) track(key string, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = defaultExpiration(c.ICache)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ttl <= 0 {
+		delete(c.entries, key)
+		return
+	}
+	entry, exists := c.entries[key]
+	if !exists {
+		entry = &refreshEntry{}
+		c.entries[key] = entry
+	}
+	entry.ttl = ttl
+	entry.expiresAt = time.Now().Add(ttl)
+}
+
+// touch 记录键的访问时间
+func (c *RefreshAheadCache) touch(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, exists := c.entries[key]; exists {
+		entry.lastAccess = time.Now()
+	}
+}
+
+// untrack 停止跟踪键
+func (c *RefreshAheadCache) untrack(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+}
+
+// Close 停止后台刷新
+func (c *RefreshAheadCache) Close() error {
+	c.once.Do(func() {
+		close(c.done)
+		c.wg.Wait()
+	})
+	return nil
+}
+
+// Set 设置缓存
+func (c *RefreshAheadCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := c.ICache.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	c.track(key, ttl)
+	return nil
+}
+
+// Get 获取缓存
+func (c *RefreshAheadCache) Get(ctx context.Context, key string, value interface{}) error {
+	if err := c.ICache.Get(ctx, key, value); err != nil {
+		return err
+	}
+	c.touch(key)
+	return nil
+}
+
+// Delete 删除缓存
+func (c *RefreshAheadCache) Delete(ctx context.Context, key string) error {
+	c.untrack(key)
+	return c.ICache.Delete(ctx, key)
+}
+
+// Clear 清空所有缓存
+func (c *RefreshAheadCache) Clear(ctx context.Context) error {
+	c.mu.Lock()
+	c.entries = make(map[string]*refreshEntry)
+	c.mu.Unlock()
+	return c.ICache.Clear(ctx)
+}
+
+// MSet 批量设置缓存
+func (c *RefreshAheadCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	if err := c.ICache.MSet(ctx, items, ttl); err != nil {
+		return err
+	}
+	for key := range items {
+		c.track(key, ttl)
+	}
+	return nil
+}
+
+// MGet 批量获取缓存
+func (c *RefreshAheadCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result, err := c.ICache.MGet(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	for key := range result {
+		c.touch(key)
+	}
+	return result, nil
+}
+
+// MDelete 批量删除缓存
+func (c *RefreshAheadCache) MDelete(ctx context.Context, keys []string) error {
+	c.untrack(keys...)
+	return c.ICache.MDelete(ctx, keys)
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间
+func (c *RefreshAheadCache) DefaultExpiration() time.Duration {
+	return defaultExpiration(c.ICache)
+}
+
+// CacheKey 返回键在底层缓存中的键名
+func (c *RefreshAheadCache) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, c.ICache, key)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRefreshAheadCache(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	inner := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	var loads atomic.Int32
+	c := WithRefreshAhead(inner, func(ctx context.Context, key string) (interface{}, error) {
+		loads.Add(1)
+		return "reloaded", nil
+	}, RefreshAheadConfig{
+		Threshold:    150 * time.Millisecond,
+		Interval:     20 * time.Millisecond,
+		AccessWindow: 200 * time.Millisecond,
+	})
+	defer c.Close()
+
+	if err := c.Set(ctx, "hot", "value", 300*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := c.Set(ctx, "cold", "value", 300*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// 持续访问热点键，超过原过期时间
+	var value string
+	for i := 0; i < 12; i++ {
+		if err := c.Get(ctx, "hot", &value); err != nil {
+			t.Fatalf("Expected hot key to stay warm, got %v after %d reads", err, i)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if value != "reloaded" {
+		t.Errorf("Expected hot key to be refreshed, got %q", value)
+	}
+	if exists, _ := c.Has(ctx, "cold"); exists {
+		t.Error("Expected cold key to expire")
+	}
+	if loads.Load() == 0 {
+		t.Error("Expected loader to be called")
+	}
+}
+
+func TestRefreshAheadDefaultExpiration(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:           100,
+		CleanupInterval:   60,
+		DefaultExpiration: 300 * time.Millisecond,
+	}
+	inner, err := NewBoltCache(config, &BoltCacheConfig{Path: filepath.Join(t.TempDir(), "cache.db")})
+	if err != nil {
+		t.Fatalf("NewBoltCache failed: %v", err)
+	}
+	defer inner.Close()
+	ctx := context.Background()
+
+	c := WithRefreshAhead(inner, func(ctx context.Context, key string) (interface{}, error) {
+		return "reloaded", nil
+	}, RefreshAheadConfig{
+		Threshold:    150 * time.Millisecond,
+		Interval:     20 * time.Millisecond,
+		AccessWindow: 200 * time.Millisecond,
+	})
+	defer c.Close()
+
+	// ttl 为0时按底层缓存的默认过期时间跟踪
+	if err := c.Set(ctx, "hot", "value", 0); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	var value string
+	for i := 0; i < 12; i++ {
+		if err := c.Get(ctx, "hot", &value); err != nil {
+			t.Fatalf("Expected hot key to stay warm, got %v after %d reads", err, i)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if value != "reloaded" {
+		t.Errorf("Expected hot key to be refreshed, got %q", value)
+	}
+}
@@ -0,0 +1,216 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// DefaultReplicationQueueSize 异步复制队列的默认容量
+const DefaultReplicationQueueSize = 1024
+
+// replicationOp 待复制到备份缓存的写操作
+type replicationOp struct {
+	op  string
+	key string
+	fn  func(ctx context.Context) error
+}
+
+// Replicator 复制缓存
+// 读操作只访问主缓存，写操作同时写入主缓存和备份缓存，写入备份缓存失败只记录日志
+type Replicator struct {
+	primary ICache
+	backup  ICache
+	queue   chan replicationOp
+	wg      sync.WaitGroup
+	mu      sync.RWMutex // 保护 closed，与关闭队列互斥
+	closed  bool
+}
+
+// NewReplicator 创建复制缓存
+// async 为 true 时通过有界队列异步写入备份缓存，队列已满时丢弃该次复制并记录日志，使用完毕后需调用 Close
+func NewReplicator(primary, backup ICache, async bool) *Replicator {
+	r := &Replicator{
+		primary: primary,
+		backup:  backup,
+	}
+	if async {
+		r.queue = make(chan replicationOp, DefaultReplicationQueueSize)
+		r.wg.Add(1)
+		go r.run()
+	}
+	return r
+}
+
+// run 依次执行队列中的复制操作
+func (r *Replicator) run() {
+	defer r.wg.Done()
+	for op := range r.queue {
+		r.logFailure(op.op, op.key, op.fn(context.Background()))
+	}
+}
+
+// Close 停止异步复制，等待队列中的操作执行完毕
+// 关闭后的写操作只写入主缓存，异步复制到备份缓存的操作被丢弃并记录日志
+func (r *Replicator) Close() error {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return nil
+	}
+	r.closed = true
+	if r.queue != nil {
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	return nil
+}
+
+// replicate 将写操作复制到备份缓存
+func (r *Replicator) replicate(ctx context.Context, op, key string, fn func(ctx context.Context) error) {
+	if r.queue == nil {
+		r.logFailure(op, key, fn(ctx))
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		logger.WithFields(map[string]interface{}{
+			"op":  op,
+			"key": key,
+		}).Warn("cache replicator closed, dropping write")
+		return
+	}
+
+	select {
+	case r.queue <- replicationOp{op: op, key: key, fn: fn}:
+	default:
+		logger.WithFields(map[string]interface{}{
+			"op":  op,
+			"key": key,
+		}).Warn("cache replication queue full, dropping write")
+	}
+}
+
+// logFailure 记录写入备份缓存时的错误
+func (r *Replicator) logFailure(op, key string, err error) {
+	if err != nil {
+		logger.WithFields(map[string]interface{}{
+			"op":    op,
+			"key":   key,
+			"error": err.Error(),
+		}).Warn("cache replication to backup failed")
+	}
+}
+
+// Set 设置缓存
+func (r *Replicator) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := r.primary.Set(ctx, key, value, ttl); err != nil {
+		return err
+	}
+	r.replicate(ctx, "Set", key, func(ctx context.Context) error {
+		return r.backup.Set(ctx, key, value, ttl)
+	})
+	return nil
+}
+
+// Get 获取缓存
+func (r *Replicator) Get(ctx context.Context, key string, value interface{}) error {
+	return r.primary.Get(ctx, key, value)
+}
+
+// Delete 删除缓存
+func (r *Replicator) Delete(ctx context.Context, key string) error {
+	if err := r.primary.Delete(ctx, key); err != nil {
+		return err
+	}
+	r.replicate(ctx, "Delete", key, func(ctx context.Context) error {
+		return r.backup.Delete(ctx, key)
+	})
+	return nil
+}
+
+// Has 检查缓存是否存在
+func (r *Replicator) Has(ctx context.Context, key string) (bool, error) {
+	return r.primary.Has(ctx, key)
+}
+
+// Clear 清空所有缓存
+func (r *Replicator) Clear(ctx context.Context) error {
+	if err := r.primary.Clear(ctx); err != nil {
+		return err
+	}
+	r.replicate(ctx, "Clear", "", r.backup.Clear)
+	return nil
+}
+
+// GetStats 获取主缓存的统计信息
+func (r *Replicator) GetStats(ctx context.Context) (*Stats, error) {
+	return r.primary.GetStats(ctx)
+}
+
+// HealthCheck 执行主缓存的健康检查
+func (r *Replicator) HealthCheck(ctx context.Context) (*Health, error) {
+	return r.primary.HealthCheck(ctx)
+}
+
+// MSet 批量设置缓存
+func (r *Replicator) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	if err := r.primary.MSet(ctx, items, ttl); err != nil {
+		return err
+	}
+	// 异步复制在返回后才执行，复制一份避免调用方修改 items
+	copied := make(map[string]interface{}, len(items))
+	for k, v := range items {
+		copied[k] = v
+	}
+	r.replicate(ctx, "MSet", "", func(ctx context.Context) error {
+		return r.backup.MSet(ctx, copied, ttl)
+	})
+	return nil
+}
+
+// MGet 批量获取缓存
+func (r *Replicator) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	return r.primary.MGet(ctx, keys)
+}
+
+// MDelete 批量删除缓存
+func (r *Replicator) MDelete(ctx context.Context, keys []string) error {
+	if err := r.primary.MDelete(ctx, keys); err != nil {
+		return err
+	}
+	copied := append([]string(nil), keys...)
+	r.replicate(ctx, "MDelete", "", func(ctx context.Context) error {
+		return r.backup.MDelete(ctx, copied)
+	})
+	return nil
+}
+
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+// 备份缓存直接写入主缓存返回的新值，避免两侧计数不一致
+func (r *Replicator) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := r.primary.IncrBy(ctx, key, delta, ttl)
+	if err != nil {
+		return 0, err
+	}
+	r.replicate(ctx, "IncrBy", key, func(ctx context.Context) error {
+		return r.backup.Set(ctx, key, value, ttl)
+	})
+	return value, nil
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间，与读写使用的主缓存一致
+func (r *Replicator) DefaultExpiration() time.Duration {
+	return defaultExpiration(r.primary)
+}
+
+// CacheKey 返回键在底层缓存中的键名
+func (r *Replicator) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, r.primary, key)
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReplicator(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		config := &BaseConfig{
+			MaxSize:         100,
+			CleanupInterval: 60,
+		}
+		primary := NewMemoryCache(config, &MemoryCacheConfig{})
+		backup := NewMemoryCache(config, &MemoryCacheConfig{})
+		r := NewReplicator(primary, backup, async)
+		ctx := context.Background()
+
+		if err := r.Set(ctx, "user:1", "alice", time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		if err := r.MSet(ctx, map[string]interface{}{"user:2": "bob"}, time.Minute); err != nil {
+			t.Fatalf("MSet failed: %v", err)
+		}
+		if _, err := r.IncrBy(ctx, "counter", 5, time.Minute); err != nil {
+			t.Fatalf("IncrBy failed: %v", err)
+		}
+		if err := r.Close(); err != nil {
+			t.Fatalf("Close failed: %v", err)
+		}
+
+		// 写操作同时到达主缓存和备份缓存
+		for _, c := range []ICache{primary, backup} {
+			var name string
+			if err := c.Get(ctx, "user:1", &name); err != nil || name != "alice" {
+				t.Errorf("async=%v: expected alice, got %q (%v)", async, name, err)
+			}
+			if exists, _ := c.Has(ctx, "user:2"); !exists {
+				t.Errorf("async=%v: expected user:2 to be replicated", async)
+			}
+			var counter int64
+			if err := c.Get(ctx, "counter", &counter); err != nil || counter != 5 {
+				t.Errorf("async=%v: expected counter 5, got %d (%v)", async, counter, err)
+			}
+		}
+
+		// 读操作只访问主缓存
+		if err := backup.Set(ctx, "backup-only", "x", time.Minute); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		if exists, _ := r.Has(ctx, "backup-only"); exists {
+			t.Errorf("async=%v: expected reads to come from primary", async)
+		}
+	}
+}
+
+func TestReplicatorAsyncAfterClose(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	primary := NewMemoryCache(config, &MemoryCacheConfig{})
+	backup := NewMemoryCache(config, &MemoryCacheConfig{})
+	r := NewReplicator(primary, backup, true)
+	ctx := context.Background()
+
+	// 入队后修改参数不影响复制的内容
+	items := map[string]interface{}{"user:1": "alice"}
+	if err := r.MSet(ctx, items, time.Minute); err != nil {
+		t.Fatalf("MSet failed: %v", err)
+	}
+	items["user:2"] = "bob"
+	keys := []string{"user:1"}
+	if err := r.MDelete(ctx, keys); err != nil {
+		t.Fatalf("MDelete failed: %v", err)
+	}
+	keys[0] = "user:3"
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("second Close failed: %v", err)
+	}
+	if exists, _ := backup.Has(ctx, "user:1"); exists {
+		t.Error("Expected user:1 to be deleted from backup")
+	}
+	if exists, _ := backup.Has(ctx, "user:2"); exists {
+		t.Error("Expected user:2 added after MSet not to be replicated")
+	}
+
+	// 关闭后的写操作只写入主缓存
+	if err := r.Set(ctx, "user:4", "dave", time.Minute); err != nil {
+		t.Fatalf("Set after Close failed: %v", err)
+	}
+	if exists, _ := primary.Has(ctx, "user:4"); !exists {
+		t.Error("Expected write after Close to reach primary")
+	}
+	if exists, _ := backup.Has(ctx, "user:4"); exists {
+		t.Error("Expected write after Close not to be replicated")
+	}
+}
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// Serializer 缓存值序列化器，文件、Redis 和 Memcached 缓存使用它编码写入的值
+type Serializer interface {
+	// Marshal 将值编码为字节
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal 将字节解码到 v 指向的值
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONSerializer JSON 序列化器，默认使用
+// 读取到 interface{} 时结构体会变为 map[string]interface{}，数字会变为 float64
+type JSONSerializer struct{}
+
+// Marshal 将值编码为 JSON
+func (JSONSerializer) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal 将 JSON 解码到 v 指向的值
+func (JSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobSerializer gob 序列化器，读取到 interface{} 时保留值的具体类型
+// 作为 interface{} 存取的自定义类型需要事先通过 gob.Register 注册
+type GobSerializer struct{}
+
+// Marshal 将值以 interface{} 的形式编码为 gob，编码结果携带具体类型信息
+func (GobSerializer) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal 将 gob 解码到 v 指向的值
+func (GobSerializer) Unmarshal(data []byte, v interface{}) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return ErrInvalidValue
+	}
+
+	var raw interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&raw); err != nil {
+		return err
+	}
+
+	src := reflect.ValueOf(raw)
+	target := ptr.Elem()
+	if src.IsValid() && !src.Type().AssignableTo(target.Type()) && src.Kind() == reflect.Ptr && !src.IsNil() {
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	if !src.Type().AssignableTo(target.Type()) {
+		return fmt.Errorf("cannot assign decoded value of type %v to value of type %v", src.Type(), target.Type())
+	}
+	target.Set(src)
+	return nil
+}
+
+var (
+	serializersMu sync.RWMutex
+	serializers   = map[string]Serializer{
+		"json": JSONSerializer{},
+		"gob":  GobSerializer{},
+	}
+)
+
+func init() {
+	// 缓存项以 interface{} 形式编码，需要注册具体类型
+	gob.Register(&fileItem{})
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// RegisterSerializer 注册自定义序列化器，注册后可通过 BaseConfig.Serializer 按名称使用
+func RegisterSerializer(name string, serializer Serializer) {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+	serializers[name] = serializer
+}
+
+// newSerializer 根据名称获取序列化器，名称为空时使用 JSON
+func newSerializer(name string) (Serializer, error) {
+	if name == "" {
+		name = "json"
+	}
+
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+	serializer, ok := serializers[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported serializer: %s", name)
+	}
+	return serializer, nil
+}
+
+// serializerOrDefault 获取序列化器，名称无效时记录错误并使用 JSON，供不返回错误的构造函数使用
+// 通过 LoadConfig 创建缓存时名称已事先校验
+func serializerOrDefault(name string) Serializer {
+	serializer, err := newSerializer(name)
+	if err != nil {
+		logger.WithFields(map[string]interface{}{
+			"serializer": name,
+			"error":      err.Error(),
+		}).Error("invalid cache serializer, falling back to json")
+		return JSONSerializer{}
+	}
+	return serializer
+}
+
+// decodeValue 使用序列化器解码缓存值
+// IncrBy 写入的计数器由服务端以整数文本保存，序列化器无法解码时按整数读取
+func decodeValue(serializer Serializer, data []byte, value interface{}) error {
+	err := serializer.Unmarshal(data, value)
+	if err == nil {
+		return nil
+	}
+	if n, perr := strconv.ParseInt(string(data), 10, 64); perr == nil {
+		return assignValue(value, n)
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"context"
+	"encoding/gob"
+	"testing"
+	"time"
+)
+
+type serializerProfile struct {
+	Name string
+	Age  int
+}
+
+func init() {
+	gob.Register(serializerProfile{})
+}
+
+func TestFileCacheGobSerializer(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+		Serializer:      "gob",
+	}
+	cache := NewFileCache(config, &FileCacheConfig{Directory: t.TempDir()})
+	ctx := context.Background()
+
+	want := serializerProfile{Name: "alice", Age: 30}
+	if err := cache.Set(ctx, "profile", want, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	var got interface{}
+	if err := cache.Get(ctx, "profile", &got); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if profile, ok := got.(serializerProfile); !ok || profile != want {
+		t.Errorf("Expected %#v, got %#v", want, got)
+	}
+
+	var typed serializerProfile
+	if err := cache.Get(ctx, "profile", &typed); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if typed != want {
+		t.Errorf("Expected %#v, got %#v", want, typed)
+	}
+
+	values, err := cache.MGet(ctx, []string{"profile"})
+	if err != nil {
+		t.Fatalf("MGet failed: %v", err)
+	}
+	if _, ok := values["profile"].(serializerProfile); !ok {
+		t.Errorf("Expected MGet to keep concrete type, got %T", values["profile"])
+	}
+}
+
+func TestSerializerRegistry(t *testing.T) {
+	if _, err := newSerializer("xml"); err == nil {
+		t.Error("Expected error for unknown serializer")
+	}
+
+	RegisterSerializer("custom-json", JSONSerializer{})
+	serializer, err := newSerializer("custom-json")
+	if err != nil {
+		t.Fatalf("newSerializer failed: %v", err)
+	}
+	if _, ok := serializer.(JSONSerializer); !ok {
+		t.Errorf("Expected registered serializer, got %T", serializer)
+	}
+}
+
+func TestUnknownSerializer(t *testing.T) {
+	config := &Config{
+		Type:       "file",
+		BaseConfig: BaseConfig{MaxSize: 100, CleanupInterval: 60, Serializer: "xml"},
+		FileConfig: FileCacheConfig{Directory: t.TempDir()},
+	}
+	if err := LoadConfig(config); err == nil {
+		t.Error("Expected LoadConfig to reject an unknown serializer")
+	}
+
+	// 直接创建时不会 panic，回退到 JSON
+	cache := NewFileCache(&config.BaseConfig, &config.FileConfig)
+	if _, ok := cache.serializer.(JSONSerializer); !ok {
+		t.Errorf("Expected fallback to JSONSerializer, got %T", cache.serializer)
+	}
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// SlowLogCache 慢操作日志缓存，耗时超过阈值的操作会被记录
+type SlowLogCache struct {
+	inner     ICache
+	threshold time.Duration
+}
+
+// WithSlowLog 为缓存添加慢操作日志，不改变任何返回值和错误
+func WithSlowLog(inner ICache, threshold time.Duration) *SlowLogCache {
+	return &SlowLogCache{
+		inner:     inner,
+		threshold: threshold,
+	}
+}
+
+// Set 设置缓存
+func (c *SlowLogCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	defer c.record("Set", key, time.Now())
+	return c.inner.Set(ctx, key, value, ttl)
+}
+
+// Get 获取缓存
+func (c *SlowLogCache) Get(ctx context.Context, key string, value interface{}) error {
+	defer c.record("Get", key, time.Now())
+	return c.inner.Get(ctx, key, value)
+}
+
+// Delete 删除缓存
+func (c *SlowLogCache) Delete(ctx context.Context, key string) error {
+	defer c.record("Delete", key, time.Now())
+	return c.inner.Delete(ctx, key)
+}
+
+// Has 检查缓存是否存在
+func (c *SlowLogCache) Has(ctx context.Context, key string) (bool, error) {
+	defer c.record("Has", key, time.Now())
+	return c.inner.Has(ctx, key)
+}
+
+// Clear 清空所有缓存
+func (c *SlowLogCache) Clear(ctx context.Context) error {
+	defer c.record("Clear", "", time.Now())
+	return c.inner.Clear(ctx)
+}
+
+// GetStats 获取缓存统计信息
+func (c *SlowLogCache) GetStats(ctx context.Context) (*Stats, error) {
+	return c.inner.GetStats(ctx)
+}
+
+// HealthCheck 执行健康检查
+func (c *SlowLogCache) HealthCheck(ctx context.Context) (*Health, error) {
+	return c.inner.HealthCheck(ctx)
+}
+
+// MSet 批量设置缓存
+func (c *SlowLogCache) MSet(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	defer c.record("MSet", strings.Join(keys, ","), time.Now())
+	return c.inner.MSet(ctx, items, ttl)
+}
+
+// MGet 批量获取缓存
+func (c *SlowLogCache) MGet(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	defer c.record("MGet", strings.Join(keys, ","), time.Now())
+	return c.inner.MGet(ctx, keys)
+}
+
+// MDelete 批量删除缓存
+func (c *SlowLogCache) MDelete(ctx context.Context, keys []string) error {
+	defer c.record("MDelete", strings.Join(keys, ","), time.Now())
+	return c.inner.MDelete(ctx, keys)
+}
+
+// IncrBy 原子地为整数缓存值增加 delta 并返回新值
+func (c *SlowLogCache) IncrBy(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	defer c.record("IncrBy", key, time.Now())
+	return c.inner.IncrBy(ctx, key, delta, ttl)
+}
+
+// Txn 执行事务，底层缓存不支持事务时返回 ErrNotImplemented
+func (c *SlowLogCache) Txn(ctx context.Context, fn func(tx Txn) error) error {
+	defer c.record("Txn", "", time.Now())
+	return RunTxn(ctx, c.inner, fn)
+}
+
+// record 记录耗时超过阈值的操作
+func (c *SlowLogCache) record(op, key string, start time.Time) {
+	duration := time.Since(start)
+	if duration < c.threshold {
+		return
+	}
+	logger.WithFields(map[string]interface{}{
+		"op":        op,
+		"key":       key,
+		"duration":  duration.String(),
+		"threshold": c.threshold.String(),
+	}).Warn("slow cache operation")
+}
+
+// DefaultExpiration 返回底层缓存的默认过期时间
+func (c *SlowLogCache) DefaultExpiration() time.Duration {
+	return defaultExpiration(c.inner)
+}
+
+// CacheKey 返回键在底层缓存中的键名
+func (c *SlowLogCache) CacheKey(ctx context.Context, key string) string {
+	return resolveCacheKey(ctx, c.inner, key)
+}
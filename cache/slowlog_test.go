@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ntshibin/core/logger"
+)
+
+// slowCache 模拟Get操作较慢的缓存
+type slowCache struct {
+	ICache
+	delay time.Duration
+}
+
+func (c *slowCache) Get(ctx context.Context, key string, value interface{}) error {
+	time.Sleep(c.delay)
+	return c.ICache.Get(ctx, key, value)
+}
+
+func TestSlowLogCache(t *testing.T) {
+	handler := logger.NewMemoryHandler(logger.NewJSONFormatter(), logger.DebugLevel, logger.DefaultMemoryConfig)
+	defaultLogger := logger.GetDefaultLogger().(*logger.StandardLogger)
+	defaultLogger.AddHandler(handler)
+	defer defaultLogger.RemoveHandler(handler)
+	logs := logger.NewMemoryHandlerAPI(handler)
+
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	inner := NewMemoryCache(config, &MemoryCacheConfig{})
+	cache := WithSlowLog(&slowCache{ICache: inner, delay: 30 * time.Millisecond}, 20*time.Millisecond)
+	ctx := context.Background()
+
+	// 快速操作不记录
+	if err := cache.Set(ctx, "fast", "value", time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if entries := logs.GetContaining("slow cache operation", 10); len(entries) != 0 {
+		t.Errorf("Expected no slow log for fast Set, got %d", len(entries))
+	}
+
+	// 慢操作记录日志，返回值不变
+	var value string
+	if err := cache.Get(ctx, "fast", &value); err != nil || value != "value" {
+		t.Fatalf("Expected Get to return value, got %q (%v)", value, err)
+	}
+	if err := cache.Get(ctx, "missing", &value); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound to pass through, got %v", err)
+	}
+
+	entries := logs.GetContaining("slow cache operation", 10)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 slow log entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Event.Fields["op"] != "Get" {
+			t.Errorf("Expected op Get, got %v", entry.Event.Fields["op"])
+		}
+		if _, ok := entry.Event.Fields["duration"]; !ok {
+			t.Error("Expected duration field")
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Snapshotter 支持快照的缓存，可将缓存内容持久化后在重启时恢复
+type Snapshotter interface {
+	// Snapshot 将未过期的缓存项写入 w
+	Snapshot(w io.Writer) error
+	// Restore 从 r 中恢复缓存项，已过期的缓存项会被忽略
+	Restore(r io.Reader) error
+}
+
+// snapshotItem 快照中的缓存项，每项编码为一行JSON
+type snapshotItem struct {
+	Key        string      `json:"key"`
+	Value      interface{} `json:"value"`
+	Expiration *time.Time  `json:"expiration,omitempty"`
+	Tags       []string    `json:"tags,omitempty"`
+}
+
+// Snapshot 将未过期的缓存项写入 w，值使用JSON编码
+func (c *MemoryCache) Snapshot(w io.Writer) error {
+	c.mutex.RLock()
+	now := time.Now()
+	items := make([]snapshotItem, 0, len(c.data))
+	for key, item := range c.data {
+		if c.expired(item, now) {
+			continue
+		}
+		s := snapshotItem{
+			Key:   key,
+			Value: item.value,
+			Tags:  item.tags,
+		}
+		if expiration, ok := c.expiresAt(item); ok {
+			s.Expiration = &expiration
+		}
+		items = append(items, s)
+	}
+	c.mutex.RUnlock()
+
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return fmt.Errorf("failed to encode snapshot item %s: %v", item.Key, err)
+		}
+	}
+	return nil
+}
+
+// Restore 从 r 中恢复缓存项，保留原有的过期时间，同名的缓存项会被覆盖
+// 恢复后的值为JSON解码后的类型，Get 时会按目标类型转换
+func (c *MemoryCache) Restore(r io.Reader) error {
+	// 先完整解码，避免快照损坏时只恢复一部分
+	var items []snapshotItem
+	decoder := json.NewDecoder(r)
+	for {
+		var item snapshotItem
+		if err := decoder.Decode(&item); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("failed to decode snapshot: %v", err)
+		}
+		items = append(items, item)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	for _, s := range items {
+		if s.Expiration != nil && now.After(*s.Expiration) {
+			continue
+		}
+
+		c.deleteItem(s.Key)
+		if len(c.data) >= c.maxSize {
+			c.evictOne(evictByPolicy)
+		}
+
+		item := &memoryItem{
+			value:      s.Value,
+			expiration: s.Expiration,
+			tags:       s.Tags,
+		}
+		c.trackSize(s.Key, item)
+		c.data[s.Key] = item
+		c.policy.Update(s.Key, item)
+		for _, tag := range s.Tags {
+			c.tags[tag] = append(c.tags[tag], s.Key)
+		}
+		c.stats.IncrKeyCount()
+		c.evictBytes()
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheSnapshotRestore(t *testing.T) {
+	config := &BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}
+	source := NewMemoryCache(config, &MemoryCacheConfig{})
+	ctx := context.Background()
+
+	if err := source.Set(ctx, "user:1", helperTestUser{ID: 1, Name: "alice"}, time.Hour); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if _, err := source.IncrBy(ctx, "counter", 7, time.Hour); err != nil {
+		t.Fatalf("IncrBy failed: %v", err)
+	}
+	if err := source.Set(ctx, "short", "gone", 50*time.Millisecond); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := source.Snapshot(&buf); err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	var restored Snapshotter = NewMemoryCache(config, &MemoryCacheConfig{})
+	if err := restored.Restore(&buf); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	target := restored.(*MemoryCache)
+
+	var user helperTestUser
+	if err := target.Get(ctx, "user:1", &user); err != nil || user != (helperTestUser{ID: 1, Name: "alice"}) {
+		t.Errorf("Expected restored user, got %+v (%v)", user, err)
+	}
+	if value, err := target.IncrBy(ctx, "counter", 1, 0); err != nil || value != 8 {
+		t.Errorf("Expected restored counter to be 8, got %d (%v)", value, err)
+	}
+	if exists, _ := target.Has(ctx, "short"); exists {
+		t.Error("Expected expired item not to be restored")
+	}
+
+	// 保留剩余的过期时间
+	target.mutex.RLock()
+	remaining := time.Until(*target.data["user:1"].expiration)
+	target.mutex.RUnlock()
+	if remaining <= 50*time.Minute || remaining > time.Hour {
+		t.Errorf("Expected remaining TTL close to 1h, got %v", remaining)
+	}
+}
@@ -1,7 +1,6 @@
 package cache
 
 import (
-	"sync"
 	"sync/atomic"
 	"time"
 )
@@ -16,117 +15,145 @@ type Stats struct {
 	Misses int64
 	// 驱逐次数
 	EvictedCount int64
+	// 因占用字节数超出上限的驱逐次数
+	EvictedBySize int64
+	// 因条目数达到上限由驱逐策略执行的驱逐次数
+	EvictedByPolicy int64
 	// 过期次数
 	ExpiredCount int64
 	// 最后更新时间
 	LastUpdate time.Time
+	// ExpiringWithin 按剩余过期时间统计的键数量，键为 ExpiryBuckets 中的时长，值为在该时长内过期的键数量
+	// 仅部分缓存实现支持，获取统计信息时实时计算
+	ExpiringWithin map[time.Duration]int64
 }
 
+// ExpiryBuckets ExpiringWithin 统计使用的时长区间
+var ExpiryBuckets = []time.Duration{time.Minute, 5 * time.Minute, time.Hour}
+
 // StatsCollector 统计信息收集器
+// 各项计数使用原子操作，并发读写缓存时记录统计信息不需要加锁
 type StatsCollector struct {
-	stats Stats
-	mutex sync.RWMutex
+	keyCount        atomic.Int64
+	hits            atomic.Int64
+	misses          atomic.Int64
+	evictedCount    atomic.Int64
+	evictedBySize   atomic.Int64
+	evictedByPolicy atomic.Int64
+	expiredCount    atomic.Int64
+	// lastUpdate 最后更新时间（UnixNano）
+	lastUpdate atomic.Int64
 }
 
 // NewStatsCollector 创建统计信息收集器
 func NewStatsCollector() *StatsCollector {
-	return &StatsCollector{
-		stats: Stats{
-			LastUpdate: time.Now(),
-		},
-	}
+	s := &StatsCollector{}
+	s.touch()
+	return s
 }
 
 // GetStats 获取统计信息
 func (s *StatsCollector) GetStats() Stats {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	return s.stats
+	return Stats{
+		KeyCount:        s.keyCount.Load(),
+		Hits:            s.hits.Load(),
+		Misses:          s.misses.Load(),
+		EvictedCount:    s.evictedCount.Load(),
+		EvictedBySize:   s.evictedBySize.Load(),
+		EvictedByPolicy: s.evictedByPolicy.Load(),
+		ExpiredCount:    s.expiredCount.Load(),
+		LastUpdate:      time.Unix(0, s.lastUpdate.Load()),
+	}
+}
+
+// touch 记录最后更新时间
+func (s *StatsCollector) touch() {
+	s.lastUpdate.Store(time.Now().UnixNano())
 }
 
 // IncrKeyCount 增加键数量
 func (s *StatsCollector) IncrKeyCount() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats.KeyCount++
-	s.stats.LastUpdate = time.Now()
+	s.IncrKeyCountBy(1)
 }
 
 // DecrKeyCount 减少键数量
 func (s *StatsCollector) DecrKeyCount() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	if s.stats.KeyCount > 0 {
-		s.stats.KeyCount--
-	}
-	s.stats.LastUpdate = time.Now()
+	s.DecrKeyCountBy(1)
 }
 
 // IncrKeyCountBy 增加指定数量的键
 func (s *StatsCollector) IncrKeyCountBy(count int64) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats.KeyCount += count
-	s.stats.LastUpdate = time.Now()
+	s.keyCount.Add(count)
+	s.touch()
 }
 
-// DecrKeyCountBy 减少指定数量的键
+// DecrKeyCountBy 减少指定数量的键，键数量最小为0
 func (s *StatsCollector) DecrKeyCountBy(count int64) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	if s.stats.KeyCount > count {
-		s.stats.KeyCount -= count
-	} else {
-		s.stats.KeyCount = 0
+	for {
+		current := s.keyCount.Load()
+		next := current - count
+		if next < 0 {
+			next = 0
+		}
+		if s.keyCount.CompareAndSwap(current, next) {
+			break
+		}
 	}
-	s.stats.LastUpdate = time.Now()
+	s.touch()
 }
 
 // IncrHits 增加命中次数
 func (s *StatsCollector) IncrHits() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats.Hits++
-	s.stats.LastUpdate = time.Now()
+	s.hits.Add(1)
+	s.touch()
 }
 
 // IncrMisses 增加未命中次数
 func (s *StatsCollector) IncrMisses() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats.Misses++
-	s.stats.LastUpdate = time.Now()
+	s.misses.Add(1)
+	s.touch()
 }
 
 // IncrEvictedCount 增加驱逐次数
 func (s *StatsCollector) IncrEvictedCount() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats.EvictedCount++
-	s.stats.LastUpdate = time.Now()
+	s.evictedCount.Add(1)
+	s.touch()
+}
+
+// IncrEvictedBySize 增加因字节上限驱逐的次数
+func (s *StatsCollector) IncrEvictedBySize() {
+	s.evictedBySize.Add(1)
+	s.touch()
+}
+
+// IncrEvictedByPolicy 增加因条目上限驱逐的次数
+func (s *StatsCollector) IncrEvictedByPolicy() {
+	s.evictedByPolicy.Add(1)
+	s.touch()
 }
 
 // IncrExpiredCount 增加过期次数
 func (s *StatsCollector) IncrExpiredCount() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats.ExpiredCount++
-	s.stats.LastUpdate = time.Now()
+	s.expiredCount.Add(1)
+	s.touch()
 }
 
 // Reset 重置统计信息
 func (s *StatsCollector) Reset() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-	s.stats = Stats{
-		LastUpdate: time.Now(),
-	}
+	s.keyCount.Store(0)
+	s.hits.Store(0)
+	s.misses.Store(0)
+	s.evictedCount.Store(0)
+	s.evictedBySize.Store(0)
+	s.evictedByPolicy.Store(0)
+	s.expiredCount.Store(0)
+	s.touch()
 }
 
 // HitRatio 获取命中率
 func (s *StatsCollector) HitRatio() float64 {
-	hits := atomic.LoadInt64(&s.stats.Hits)
-	total := hits + atomic.LoadInt64(&s.stats.Misses)
+	hits := s.hits.Load()
+	total := hits + s.misses.Load()
 	if total == 0 {
 		return 0
 	}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Txn 事务操作集合，操作会被缓存，在事务函数返回后一次性提交
+type Txn interface {
+	// Set 设置缓存
+	Set(key string, value interface{}, ttl time.Duration)
+	// Delete 删除缓存
+	Delete(key string)
+	// IncrBy 为整数缓存值增加 delta
+	IncrBy(key string, delta int64, ttl time.Duration)
+}
+
+// Transactional 支持事务的缓存
+type Transactional interface {
+	// Txn 执行事务，fn 返回错误或任一操作校验失败时不写入任何数据
+	Txn(ctx context.Context, fn func(tx Txn) error) error
+}
+
+// RunTxn 在支持事务的缓存上执行事务
+func RunTxn(ctx context.Context, cache ICache, fn func(tx Txn) error) error {
+	t, ok := cache.(Transactional)
+	if !ok {
+		return fmt.Errorf("%w: cache does not support transactions", ErrNotImplemented)
+	}
+	return t.Txn(ctx, fn)
+}
+
+// txnOpType 事务操作类型
+type txnOpType int
+
+const (
+	txnSet txnOpType = iota
+	txnDelete
+	txnIncrBy
+)
+
+// txnOp 事务中的单个操作
+type txnOp struct {
+	typ   txnOpType
+	key   string
+	value interface{}
+	delta int64
+	ttl   time.Duration
+}
+
+// txnBuffer 缓存事务操作
+type txnBuffer struct {
+	ops []txnOp
+}
+
+// Set 设置缓存
+func (b *txnBuffer) Set(key string, value interface{}, ttl time.Duration) {
+	b.ops = append(b.ops, txnOp{typ: txnSet, key: key, value: value, ttl: ttl})
+}
+
+// Delete 删除缓存
+func (b *txnBuffer) Delete(key string) {
+	b.ops = append(b.ops, txnOp{typ: txnDelete, key: key})
+}
+
+// IncrBy 为整数缓存值增加 delta
+func (b *txnBuffer) IncrBy(key string, delta int64, ttl time.Duration) {
+	b.ops = append(b.ops, txnOp{typ: txnIncrBy, key: key, delta: delta, ttl: ttl})
+}
+
+// collectTxn 执行事务函数并返回缓存的操作
+func collectTxn(fn func(tx Txn) error) ([]txnOp, error) {
+	buffer := &txnBuffer{}
+	if err := fn(buffer); err != nil {
+		return nil, err
+	}
+	return buffer.ops, nil
+}
+
+// validateTxn 按顺序模拟事务操作，检查计数器操作的当前值是否为整数
+// lookup 返回键的当前值以及键是否存在
+func validateTxn(ops []txnOp, lookup func(key string) (interface{}, bool, error)) error {
+	type pending struct {
+		value  interface{}
+		exists bool
+	}
+	overlay := make(map[string]pending)
+
+	for _, op := range ops {
+		switch op.typ {
+		case txnSet:
+			overlay[op.key] = pending{value: op.value, exists: true}
+		case txnDelete:
+			overlay[op.key] = pending{}
+		case txnIncrBy:
+			p, ok := overlay[op.key]
+			if !ok {
+				value, exists, err := lookup(op.key)
+				if err != nil {
+					return err
+				}
+				p = pending{value: value, exists: exists}
+			}
+
+			current := int64(0)
+			if p.exists {
+				n, ok := toInt64(p.value)
+				if !ok {
+					return fmt.Errorf("%w: %s", ErrNotInteger, op.key)
+				}
+				current = n
+			}
+			overlay[op.key] = pending{value: current + op.delta, exists: true}
+		}
+	}
+	return nil
+}
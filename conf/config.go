@@ -3,7 +3,9 @@ package conf
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,38 +16,80 @@ import (
 
 // LoadConfig 从文件加载配置到结构体
 // config 应该是指向结构体的指针
-func LoadConfig(file string, config interface{}) error {
+func LoadConfig(file string, config interface{}, opts ...LoadOption) error {
 	content, err := os.ReadFile(file)
 	if err != nil {
 		return err
 	}
 
+	// 根据文件扩展名选择解析方式
+	return loadContent(strings.ToLower(filepath.Ext(file)), content, config, opts)
+}
+
+// LoadOrDefault 从文件加载配置，文件不存在时不返回错误，而是使用 default 标签中的默认值
+// 文件存在时与 LoadConfig 相同，文件中未设置的字段同样使用默认值，解析错误仍会返回
+func LoadOrDefault(file string, config interface{}, opts ...LoadOption) error {
+	if _, err := os.Stat(file); errors.Is(err, fs.ErrNotExist) {
+		if err := applyDefaults(config); err != nil {
+			return err
+		}
+		return resolveSelfRefs(config)
+	}
+	return LoadConfig(file, config, opts...)
+}
+
+// loadContent 替换环境变量后按扩展名解析配置内容，并检查废弃字段
+// 解析前先将 default 标签中的默认值写入零值字段，配置内容中设置的值会覆盖默认值
+func loadContent(ext string, content []byte, config interface{}, opts []LoadOption) error {
 	// 替换环境变量
 	expandedContent := expandEnvVars(string(content))
 
-	// 根据文件扩展名选择解析方式
-	ext := strings.ToLower(filepath.Ext(file))
+	// 写入默认值
+	if err := applyDefaults(config); err != nil {
+		return err
+	}
+
+	// 记录废弃字段加载前的值
+	deprecations := newDeprecationCheck(config)
+
+	var err error
+	if newLoadOptions(opts).strict {
+		err = decodeStrict(ext, []byte(expandedContent), config)
+	} else {
+		err = decode(ext, []byte(expandedContent), config)
+	}
+	if err != nil {
+		return err
+	}
+
+	// 检查废弃字段
+	if deprecations != nil {
+		deprecations.apply()
+	}
+
+	// 替换对同一配置中其他字段的引用
+	return resolveSelfRefs(config)
+}
+
+// decode 根据文件扩展名解析配置内容
+func decode(ext string, content []byte, config interface{}) error {
+	var err error
 	switch ext {
 	case ".yaml", ".yml":
-		err = yaml.Unmarshal([]byte(expandedContent), config)
+		err = yaml.Unmarshal(content, config)
 	case ".json":
-		err = json.Unmarshal([]byte(expandedContent), config)
+		err = json.Unmarshal(content, config)
 	case ".toml":
-		err = toml.Unmarshal([]byte(expandedContent), config)
+		err = toml.Unmarshal(content, config)
 	default:
 		return fmt.Errorf("unsupported config file format: %s", ext)
 	}
-
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
 // MustLoad 从文件加载配置，若失败则panic
-func MustLoad(file string, config interface{}) {
-	if err := LoadConfig(file, config); err != nil {
+func MustLoad(file string, config interface{}, opts ...LoadOption) {
+	if err := LoadConfig(file, config, opts...); err != nil {
 		panic(err)
 	}
 }
@@ -55,7 +99,7 @@ func MustLoad(file string, config interface{}) {
 // 1. config_[env].yaml (如: config_dev.yaml)
 // 2. config.yaml (作为默认)
 // 环境变量 RUN_MODE 决定当前环境，如未设置则为dev
-func LoadConfigByEnv(baseFilename string, config interface{}) error {
+func LoadConfigByEnv(baseFilename string, config interface{}, opts ...LoadOption) error {
 	// 获取不带扩展名的文件名和扩展名
 	ext := filepath.Ext(baseFilename)
 	base := baseFilename[:len(baseFilename)-len(ext)]
@@ -68,17 +112,17 @@ func LoadConfigByEnv(baseFilename string, config interface{}) error {
 	envFile := FindConfigFile(envFilename)
 	if _, err := os.Stat(envFile); err == nil {
 		// 找到环境特定的配置文件
-		return LoadConfig(envFile, config)
+		return LoadConfig(envFile, config, opts...)
 	}
 
 	// 尝试加载基础配置文件
 	baseFile := FindConfigFile(baseFilename)
-	return LoadConfig(baseFile, config)
+	return LoadConfig(baseFile, config, opts...)
 }
 
 // MustLoadByEnv 根据当前环境加载配置，若失败则panic
-func MustLoadByEnv(baseFilename string, config interface{}) {
-	if err := LoadConfigByEnv(baseFilename, config); err != nil {
+func MustLoadByEnv(baseFilename string, config interface{}, opts ...LoadOption) {
+	if err := LoadConfigByEnv(baseFilename, config, opts...); err != nil {
 		panic(err)
 	}
 }
@@ -0,0 +1,210 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type strictTestConfig struct {
+	Server struct {
+		Host string `yaml:"host" json:"host" toml:"host"`
+		Port int    `yaml:"port" json:"port" toml:"port"`
+	} `yaml:"server" json:"server" toml:"server"`
+}
+
+func TestLoadConfigStrict(t *testing.T) {
+	files := map[string]string{
+		"config.yaml": "server:\n  host: localhost\n  prot: 8080\n  hots: example.com\n",
+		"config.json": `{"server": {"host": "localhost", "prot": 8080, "hots": "example.com"}}`,
+		"config.toml": "[server]\nhost = \"localhost\"\nprot = 8080\nhots = \"example.com\"\n",
+	}
+
+	dir := t.TempDir()
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+
+		var config strictTestConfig
+		if err := LoadConfig(file, &config); err != nil {
+			t.Errorf("%s: expected non-strict load to succeed, got %v", name, err)
+		}
+
+		err := LoadConfig(file, &config, WithStrict())
+		if err == nil {
+			t.Errorf("%s: expected strict load to fail", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), "prot") || !strings.Contains(err.Error(), "hots") {
+			t.Errorf("%s: expected error to name every unknown key, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadConfigSelfReference(t *testing.T) {
+	type config struct {
+		Database struct {
+			Host string `yaml:"host"`
+			Port int    `yaml:"port"`
+		} `yaml:"database"`
+		DSN     string `yaml:"dsn"`
+		BaseURL string `yaml:"base_url"`
+		A       string `yaml:"a"`
+		B       string `yaml:"b"`
+	}
+
+	dir := t.TempDir()
+	write := func(content string) string {
+		file := filepath.Join(dir, "config.yaml")
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		return file
+	}
+
+	var c config
+	file := write(`
+database:
+  host: db.local
+  port: 5432
+dsn: "postgres://${self.Database.Host}:${self.database.port}/app"
+base_url: "${self.dsn}?sslmode=disable"
+`)
+	if err := LoadConfig(file, &c); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if c.DSN != "postgres://db.local:5432/app" {
+		t.Errorf("Unexpected dsn: %s", c.DSN)
+	}
+	if c.BaseURL != "postgres://db.local:5432/app?sslmode=disable" {
+		t.Errorf("Unexpected base_url: %s", c.BaseURL)
+	}
+
+	var cyclic config
+	file = write(`
+a: "${self.b}"
+b: "${self.a}"
+`)
+	if err := LoadConfig(file, &cyclic); err == nil || !strings.Contains(err.Error(), "circular self reference") {
+		t.Errorf("Expected circular reference error, got %v", err)
+	}
+}
+
+func TestLoadOrDefault(t *testing.T) {
+	type config struct {
+		Server struct {
+			Host    string        `yaml:"host" default:"${LOAD_OR_DEFAULT_HOST:-localhost}"`
+			Port    int           `yaml:"port" default:"8080"`
+			Timeout time.Duration `yaml:"timeout" default:"5s"`
+		} `yaml:"server"`
+		Debug bool   `yaml:"debug" default:"true"`
+		URL   string `yaml:"url" default:"http://${self.server.host}"`
+	}
+
+	var c config
+	if err := LoadOrDefault(filepath.Join(t.TempDir(), "missing.yaml"), &c); err != nil {
+		t.Fatalf("LoadOrDefault failed: %v", err)
+	}
+	if c.Server.Host != "localhost" || c.Server.Port != 8080 || c.Server.Timeout != 5*time.Second || !c.Debug {
+		t.Errorf("Expected defaults to be applied, got %+v", c)
+	}
+	if c.URL != "http://localhost" {
+		t.Errorf("Expected self reference in default to be resolved, got %s", c.URL)
+	}
+
+	// 文件存在时，文件中未设置的字段使用默认值，显式设置的零值不会被默认值覆盖
+	partial := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(partial, []byte("server:\n  port: 9090\ndebug: false\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var loaded config
+	if err := LoadOrDefault(partial, &loaded); err != nil {
+		t.Fatalf("LoadOrDefault failed: %v", err)
+	}
+	if loaded.Server.Host != "localhost" || loaded.Server.Port != 9090 || loaded.Server.Timeout != 5*time.Second || loaded.Debug {
+		t.Errorf("Expected missing keys to use defaults, got %+v", loaded)
+	}
+	if loaded.URL != "http://localhost" {
+		t.Errorf("Expected self reference in default to be resolved, got %s", loaded.URL)
+	}
+
+	// 文件存在时解析错误仍会返回
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("server: [invalid"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := LoadOrDefault(file, &c); err == nil {
+		t.Error("Expected parse error for existing file")
+	}
+}
+
+func TestLoadConfigSchemaIsLazy(t *testing.T) {
+	type config struct {
+		Name string `yaml:"name" validate:"required"`
+	}
+	ClearTypeCache()
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("name: app\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var c config
+	if err := LoadConfig(file, &c); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	info := cachedTypeInfo(reflect.TypeOf(c))
+	if info.schema.Fields != nil {
+		t.Errorf("Expected schema not to be derived by LoadConfig, got %v", info.schema.Fields)
+	}
+	if schema, err := DeriveSchema(&c); err != nil || len(schema.Fields) != 1 {
+		t.Errorf("Expected schema to be derived on demand, got %v (%v)", schema.Fields, err)
+	}
+}
+
+type benchmarkConfig struct {
+	Server struct {
+		Host      string `yaml:"host" validate:"required"`
+		Port      int    `yaml:"port" default:"8080"`
+		Timeout   int    `yaml:"timeout" deprecated:"use timeout_ms instead" migrate:"server.timeout_ms"`
+		TimeoutMS int    `yaml:"timeout_ms"`
+	} `yaml:"server"`
+	Database struct {
+		DSN      string `yaml:"dsn"`
+		MaxConns int    `yaml:"max_conns"`
+	} `yaml:"database"`
+}
+
+func BenchmarkLoadConfig(b *testing.B) {
+	file := filepath.Join(b.TempDir(), "config.yaml")
+	content := "server:\n  host: localhost\n  port: 8080\ndatabase:\n  dsn: postgres://localhost\n  max_conns: 10\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		b.Fatalf("WriteFile failed: %v", err)
+	}
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var config benchmarkConfig
+			if err := LoadConfig(file, &config); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			ClearTypeCache()
+			var config benchmarkConfig
+			if err := LoadConfig(file, &config); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package conf
+
+import (
+	"fmt"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// applyDefaults 将 default 标签中的默认值写入零值字段，默认值支持环境变量替换
+func applyDefaults(config interface{}) error {
+	v := reflect.ValueOf(config)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("config must be a non-nil pointer, got %T", config)
+	}
+	v = v.Elem()
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("config must point to a struct, got %v", v.Kind())
+	}
+
+	info := cachedTypeInfo(v.Type())
+	if !info.hasDefaults {
+		return nil
+	}
+
+	for _, fs := range info.getSchema().Fields {
+		if fs.Default == "" {
+			continue
+		}
+		field, ok := lookupPath(v, fs.Path)
+		if !ok || !field.CanSet() || !field.IsZero() {
+			continue
+		}
+
+		value := expandEnvVars(fs.Default)
+		if field.Kind() == reflect.String {
+			field.SetString(value)
+			continue
+		}
+		if err := yaml.Unmarshal([]byte(value), field.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid default value for %s: %v", fs.Path, err)
+		}
+	}
+	return nil
+}
+
+// hasDefaultTag 检查结构体中是否存在带有 default 标签的字段
+// visiting 记录当前路径上的结构体类型，类型重复出现时不再展开
+func hasDefaultTag(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, _, ok := configFieldName(field); !ok {
+			continue
+		}
+		if _, exists := field.Tag.Lookup("default"); exists {
+			return true
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && schemaType(ft) == "" && !visiting[ft] {
+			visiting[ft] = true
+			found := hasDefaultTag(ft, visiting)
+			delete(visiting, ft)
+			if found {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,157 @@
+package conf
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+)
+
+// 配置警告输出
+var (
+	warnMu   sync.RWMutex
+	warnFunc = func(msg string) {
+		log.Printf("[conf] WARN %s", msg)
+	}
+)
+
+// SetWarnFunc 设置配置警告的输出函数，默认使用标准库 log 输出
+// 导入 logger 包时会自动改为通过 logger 输出
+func SetWarnFunc(fn func(msg string)) {
+	if fn == nil {
+		return
+	}
+	warnMu.Lock()
+	defer warnMu.Unlock()
+	warnFunc = fn
+}
+
+// warn 输出配置警告
+func warn(format string, args ...interface{}) {
+	warnMu.RLock()
+	fn := warnFunc
+	warnMu.RUnlock()
+	fn(fmt.Sprintf(format, args...))
+}
+
+// deprecatedField 已废弃的配置字段
+type deprecatedField struct {
+	// path 字段路径
+	path string
+	// message 废弃说明，取自 deprecated 标签
+	message string
+	// migrate 替代字段的路径，取自 migrate 标签
+	migrate string
+}
+
+// findDeprecated 收集结构体中带有 deprecated 标签的字段
+// visiting 记录当前路径上的结构体类型，类型重复出现时（如自引用的结构体）不再展开
+func findDeprecated(t reflect.Type, prefix string, visiting map[reflect.Type]bool, fields []deprecatedField) []deprecatedField {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, ok := configFieldName(field)
+		if !ok {
+			continue
+		}
+
+		path := joinPath(prefix, name)
+		if inline {
+			path = prefix
+		}
+
+		if message, exists := field.Tag.Lookup("deprecated"); exists {
+			fields = append(fields, deprecatedField{
+				path:    path,
+				message: message,
+				migrate: field.Tag.Get("migrate"),
+			})
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && schemaType(ft) == "" && !visiting[ft] {
+			visiting[ft] = true
+			fields = findDeprecated(ft, path, visiting, fields)
+			delete(visiting, ft)
+		}
+	}
+	return fields
+}
+
+// deprecationCheck 记录加载前废弃字段的值，用于判断字段是否由配置文件设置
+type deprecationCheck struct {
+	target reflect.Value
+	fields []deprecatedField
+	before []interface{}
+	// migrateBefore 替代字段加载前的值，替代字段未被配置内容修改时才迁移
+	migrateBefore []interface{}
+}
+
+// newDeprecationCheck 在加载配置前创建废弃字段检查，没有废弃字段时返回 nil
+func newDeprecationCheck(config interface{}) *deprecationCheck {
+	v := reflect.ValueOf(config)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := cachedTypeInfo(v.Elem().Type()).deprecated
+	if len(fields) == 0 {
+		return nil
+	}
+
+	check := &deprecationCheck{
+		target:        v,
+		fields:        fields,
+		before:        make([]interface{}, len(fields)),
+		migrateBefore: make([]interface{}, len(fields)),
+	}
+	for i, f := range fields {
+		if fv, ok := lookupPath(v, f.path); ok {
+			check.before[i] = deepCopy(fv).Interface()
+		}
+		if f.migrate == "" {
+			continue
+		}
+		if mv, ok := lookupPath(v, f.migrate); ok {
+			check.migrateBefore[i] = deepCopy(mv).Interface()
+		}
+	}
+	return check
+}
+
+// apply 对加载后被设置的废弃字段输出警告，并迁移到替代字段
+// 替代字段由配置内容设置时不会被覆盖，仅为默认值时使用旧字段的值
+func (c *deprecationCheck) apply() {
+	for i, f := range c.fields {
+		fv, ok := lookupPath(c.target, f.path)
+		if !ok || fv.IsZero() || reflect.DeepEqual(c.before[i], fv.Interface()) {
+			continue
+		}
+
+		warn("config field %s is deprecated: %s", f.path, f.message)
+
+		if f.migrate == "" {
+			continue
+		}
+		target, ok := lookupPath(c.target, f.migrate)
+		if !ok || !target.CanSet() {
+			warn("config field %s cannot be migrated to %s: field not found", f.path, f.migrate)
+			continue
+		}
+		if !target.IsZero() && !reflect.DeepEqual(c.migrateBefore[i], target.Interface()) {
+			continue
+		}
+
+		switch {
+		case fv.Type().AssignableTo(target.Type()):
+			target.Set(fv)
+		case fv.Type().ConvertibleTo(target.Type()):
+			target.Set(fv.Convert(target.Type()))
+		default:
+			warn("config field %s cannot be migrated to %s: type %s is not compatible with %s", f.path, f.migrate, fv.Type(), target.Type())
+		}
+	}
+}
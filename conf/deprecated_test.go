@@ -0,0 +1,119 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type deprecatedTestConfig struct {
+	Server struct {
+		Timeout   int `yaml:"timeout" deprecated:"use timeout_ms instead" migrate:"server.timeout_ms"`
+		TimeoutMS int `yaml:"timeout_ms"`
+	} `yaml:"server"`
+}
+
+func TestLoadConfigDeprecatedField(t *testing.T) {
+	var warnings []string
+	SetWarnFunc(func(msg string) {
+		warnings = append(warnings, msg)
+	})
+	defer SetWarnFunc(func(msg string) {})
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("server:\n  timeout: 3000\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	var config deprecatedTestConfig
+	if err := LoadConfig(file, &config); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("Expected 1 warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], "server.timeout") || !strings.Contains(warnings[0], "use timeout_ms instead") {
+		t.Errorf("Expected warning to name the field and replacement, got %s", warnings[0])
+	}
+	if config.Server.TimeoutMS != 3000 {
+		t.Errorf("Expected value to migrate to timeout_ms, got %d", config.Server.TimeoutMS)
+	}
+
+	// 未使用废弃字段时不输出警告
+	warnings = nil
+	if err := os.WriteFile(file, []byte("server:\n  timeout_ms: 500\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var fresh deprecatedTestConfig
+	if err := LoadConfig(file, &fresh); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
+
+type deprecatedNode struct {
+	Name    string          `yaml:"name"`
+	OldName string          `yaml:"old_name" deprecated:"use name instead" migrate:"name"`
+	Child   *deprecatedNode `yaml:"child"`
+}
+
+func TestLoadConfigSelfReferencingType(t *testing.T) {
+	var warnings []string
+	SetWarnFunc(func(msg string) {
+		warnings = append(warnings, msg)
+	})
+	defer SetWarnFunc(func(msg string) {})
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("old_name: root\nchild:\n  name: leaf\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	var config deprecatedNode
+	if err := LoadConfig(file, &config); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.Name != "root" || config.Child == nil || config.Child.Name != "leaf" {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected 1 warning for the top-level deprecated field, got %v", warnings)
+	}
+}
+
+func TestLoadConfigDeprecatedFieldWithDefault(t *testing.T) {
+	SetWarnFunc(func(msg string) {})
+
+	type config struct {
+		Timeout   int `yaml:"timeout" deprecated:"use timeout_ms instead" migrate:"timeout_ms"`
+		TimeoutMS int `yaml:"timeout_ms" default:"1000"`
+	}
+
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(file, []byte("timeout: 3000\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var migrated config
+	if err := LoadConfig(file, &migrated); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if migrated.TimeoutMS != 3000 {
+		t.Errorf("Expected deprecated value to replace the default, got %d", migrated.TimeoutMS)
+	}
+
+	// 替代字段由配置文件设置时不会被覆盖
+	if err := os.WriteFile(file, []byte("timeout: 3000\ntimeout_ms: 500\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	var explicit config
+	if err := LoadConfig(file, &explicit); err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if explicit.TimeoutMS != 500 {
+		t.Errorf("Expected explicit timeout_ms to be kept, got %d", explicit.TimeoutMS)
+	}
+}
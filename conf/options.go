@@ -0,0 +1,168 @@
+package conf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadOption 配置加载选项
+type LoadOption func(*loadOptions)
+
+// loadOptions 配置加载选项集合
+type loadOptions struct {
+	strict bool
+}
+
+// WithStrict 启用严格模式，配置文件中存在结构体未声明的键时返回错误
+func WithStrict() LoadOption {
+	return func(o *loadOptions) {
+		o.strict = true
+	}
+}
+
+// newLoadOptions 应用加载选项
+func newLoadOptions(opts []LoadOption) *loadOptions {
+	o := &loadOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// yamlUnknownField 从 yaml 错误信息中提取未知的键
+var yamlUnknownField = regexp.MustCompile(`field (\S+) not found in type`)
+
+// decodeStrict 以严格模式解析配置内容，收集所有未知的键
+func decodeStrict(ext string, content []byte, config interface{}) error {
+	var unknown []string
+
+	switch ext {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+		decoder.KnownFields(true)
+		err := decoder.Decode(config)
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			var others []string
+			for _, msg := range typeErr.Errors {
+				if m := yamlUnknownField.FindStringSubmatch(msg); m != nil {
+					unknown = append(unknown, m[1])
+				} else {
+					others = append(others, msg)
+				}
+			}
+			if len(others) > 0 {
+				return err
+			}
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	case ".json":
+		// DisallowUnknownFields 遇到第一个未知的键就会停止，因此先正常解析，再与结构体比较收集全部未知的键
+		if err := json.Unmarshal(content, config); err != nil {
+			return err
+		}
+		var raw interface{}
+		if err := json.Unmarshal(content, &raw); err != nil {
+			return err
+		}
+		unknown = jsonUnknownKeys(raw, reflect.TypeOf(config), unknown)
+	case ".toml":
+		meta, err := toml.Decode(string(content), config)
+		if err != nil {
+			return err
+		}
+		for _, key := range meta.Undecoded() {
+			unknown = append(unknown, key.String())
+		}
+	default:
+		return fmt.Errorf("unsupported config file format: %s", ext)
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown config keys: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// jsonUnknownKeys 收集 JSON 数据中结构体未声明的键，与 encoding/json 一致按名称忽略大小写匹配
+func jsonUnknownKeys(data interface{}, t reflect.Type, unknown []string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch v := data.(type) {
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t, nil)
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				value := v[key]
+				ft, ok := fields[strings.ToLower(key)]
+				if !ok {
+					unknown = append(unknown, key)
+					continue
+				}
+				unknown = jsonUnknownKeys(value, ft, unknown)
+			}
+		case reflect.Map:
+			for _, value := range v {
+				unknown = jsonUnknownKeys(value, t.Elem(), unknown)
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, value := range v {
+				unknown = jsonUnknownKeys(value, t.Elem(), unknown)
+			}
+		}
+	}
+	return unknown
+}
+
+// jsonFields 返回结构体在 JSON 中的键（小写）及对应的字段类型，匿名嵌入的结构体会被展开
+func jsonFields(t reflect.Type, fields map[string]reflect.Type) map[string]reflect.Type {
+	if fields == nil {
+		fields = make(map[string]reflect.Type)
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && tag == "" && ft.Kind() == reflect.Struct {
+			jsonFields(ft, fields)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+	return fields
+}
@@ -0,0 +1,257 @@
+package conf
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Schema 与Go结构体无关的配置结构声明，可序列化为JSON以便跨语言共享
+type Schema struct {
+	Fields []FieldSchema `json:"fields"`
+}
+
+// FieldSchema 单个配置字段的声明
+type FieldSchema struct {
+	// Path 字段路径，使用点分隔的配置键，如 server.port
+	Path string `json:"path"`
+	// Type 字段类型：string, int, uint, float, bool, duration, time, array, map
+	Type string `json:"type"`
+	// Default 默认值
+	Default string `json:"default,omitempty"`
+	// Required 是否必填
+	Required bool `json:"required,omitempty"`
+	// Validate 校验规则，语法与 validate 标签相同
+	Validate string `json:"validate,omitempty"`
+}
+
+// JSON 将Schema序列化为JSON
+func (s Schema) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// DeriveSchema 根据结构体的反射信息生成Schema
+// 字段路径取自 yaml 标签，默认值取自 default 标签，校验规则取自 validate 标签
+func DeriveSchema(v interface{}) (Schema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return Schema{}, fmt.Errorf("schema can only be derived from a struct, got %v", t)
+	}
+
+	// 复制缓存的字段列表，避免调用方修改影响缓存
+	cached := cachedTypeInfo(t).getSchema()
+	schema := Schema{Fields: append([]FieldSchema(nil), cached.Fields...)}
+	return schema, nil
+}
+
+// deriveFields 递归收集结构体字段
+// visiting 记录当前路径上的结构体类型，类型重复出现时（如自引用的结构体）不再展开
+func deriveFields(t reflect.Type, prefix string, visiting map[reflect.Type]bool, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, ok := configFieldName(field)
+		if !ok {
+			continue
+		}
+
+		path := joinPath(prefix, name)
+		if inline {
+			path = prefix
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && schemaType(ft) == "" {
+			if !visiting[ft] {
+				visiting[ft] = true
+				deriveFields(ft, path, visiting, schema)
+				delete(visiting, ft)
+			}
+			continue
+		}
+
+		rule := field.Tag.Get("validate")
+		schema.Fields = append(schema.Fields, FieldSchema{
+			Path:     path,
+			Type:     schemaType(ft),
+			Default:  field.Tag.Get("default"),
+			Required: hasRule(rule, "required"),
+			Validate: rule,
+		})
+	}
+}
+
+// ValidateAgainstSchema 检查已加载的配置是否满足Schema中的全部约束
+func ValidateAgainstSchema(target interface{}, schema Schema) error {
+	v := reflect.ValueOf(target)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return fmt.Errorf("config target is nil")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("config target must be a struct, got %v", v.Kind())
+	}
+
+	values := make(map[string]reflect.Value)
+	collectValues(v, "", map[reflect.Type]bool{v.Type(): true}, values)
+
+	var problems []string
+	for _, fs := range schema.Fields {
+		value, ok := values[fs.Path]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: field not found", fs.Path))
+			continue
+		}
+
+		if actual := schemaType(value.Type()); fs.Type != "" && actual != fs.Type {
+			problems = append(problems, fmt.Sprintf("%s: expected type %s, got %s", fs.Path, fs.Type, actual))
+			continue
+		}
+
+		if fs.Required && value.IsZero() {
+			problems = append(problems, fmt.Sprintf("%s: field is required", fs.Path))
+			continue
+		}
+
+		if fs.Validate != "" {
+			if err := validate.Var(value.Interface(), fs.Validate); err != nil {
+				if verrs, ok := err.(validator.ValidationErrors); ok && len(verrs) > 0 {
+					problems = append(problems, fmt.Sprintf("%s: failed on the '%s' rule", fs.Path, verrs[0].Tag()))
+				} else {
+					problems = append(problems, fmt.Sprintf("%s: %v", fs.Path, err))
+				}
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("config does not satisfy schema: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// collectValues 按字段路径收集结构体中的叶子字段值
+// 与 deriveFields 一致，当前路径上重复出现的结构体类型不再展开
+func collectValues(v reflect.Value, prefix string, visiting map[reflect.Type]bool, values map[string]reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, inline, ok := configFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+
+		path := joinPath(prefix, name)
+		if inline {
+			path = prefix
+		}
+
+		fv := v.Field(i)
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				fv = reflect.Zero(fv.Type().Elem())
+				break
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Struct && schemaType(fv.Type()) == "" {
+			if !visiting[fv.Type()] {
+				visiting[fv.Type()] = true
+				collectValues(fv, path, visiting, values)
+				delete(visiting, fv.Type())
+			}
+			continue
+		}
+		values[path] = fv
+	}
+}
+
+// configFieldName 返回字段在配置文件中的键名
+// 依次使用 yaml、json 标签，未设置时与 yaml 包一致使用小写字段名
+func configFieldName(field reflect.StructField) (name string, inline bool, ok bool) {
+	if field.PkgPath != "" {
+		return "", false, false
+	}
+
+	for _, key := range []string{"yaml", "json"} {
+		tag, exists := field.Tag.Lookup(key)
+		if !exists {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if parts[0] == "-" {
+			return "", false, false
+		}
+		for _, opt := range parts[1:] {
+			if opt == "inline" {
+				return "", true, true
+			}
+		}
+		if parts[0] != "" {
+			return parts[0], false, true
+		}
+	}
+
+	if field.Anonymous {
+		return "", true, true
+	}
+	return strings.ToLower(field.Name), false, true
+}
+
+// schemaType 返回类型在Schema中的名称，普通结构体返回空字符串
+func schemaType(t reflect.Type) string {
+	switch t {
+	case reflect.TypeOf(time.Duration(0)):
+		return "duration"
+	case reflect.TypeOf(time.Time{}):
+		return "time"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map:
+		return "map"
+	case reflect.Ptr:
+		return schemaType(t.Elem())
+	}
+	return ""
+}
+
+// joinPath 拼接字段路径
+func joinPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// hasRule 检查校验规则中是否包含指定规则
+func hasRule(rules, name string) bool {
+	for _, rule := range strings.Split(rules, ",") {
+		if strings.TrimSpace(rule) == name {
+			return true
+		}
+	}
+	return false
+}
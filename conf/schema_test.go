@@ -0,0 +1,185 @@
+package conf
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SchemaTestBase struct {
+	Level string `yaml:"level" default:"info"`
+}
+
+type schemaNode struct {
+	Name  string      `yaml:"name" validate:"required"`
+	Child *schemaNode `yaml:"child"`
+}
+
+func TestDeriveSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		config interface{}
+		want   []FieldSchema
+	}{
+		{
+			name: "nested",
+			config: struct {
+				Server struct {
+					Host string `yaml:"host" default:"localhost"`
+					Port int    `yaml:"port" validate:"required,min=1"`
+				} `yaml:"server"`
+			}{},
+			want: []FieldSchema{
+				{Path: "server.host", Type: "string", Default: "localhost"},
+				{Path: "server.port", Type: "int", Required: true, Validate: "required,min=1"},
+			},
+		},
+		{
+			name: "inline",
+			config: struct {
+				SchemaTestBase `yaml:",inline"`
+				Name           string `yaml:"name"`
+			}{},
+			want: []FieldSchema{
+				{Path: "level", Type: "string", Default: "info"},
+				{Path: "name", Type: "string"},
+			},
+		},
+		{
+			name: "pointer",
+			config: &struct {
+				Database *struct {
+					DSN string `yaml:"dsn"`
+				} `yaml:"database"`
+				Timeout *time.Duration `yaml:"timeout" default:"5s"`
+				Tags    []string       `yaml:"tags"`
+			}{},
+			want: []FieldSchema{
+				{Path: "database.dsn", Type: "string"},
+				{Path: "timeout", Type: "duration", Default: "5s"},
+				{Path: "tags", Type: "array"},
+			},
+		},
+		{
+			name:   "self referencing",
+			config: schemaNode{},
+			want: []FieldSchema{
+				{Path: "name", Type: "string", Required: true, Validate: "required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := DeriveSchema(tt.config)
+			if err != nil {
+				t.Fatalf("DeriveSchema failed: %v", err)
+			}
+			if !reflect.DeepEqual(schema.Fields, tt.want) {
+				t.Errorf("Expected %+v, got %+v", tt.want, schema.Fields)
+			}
+		})
+	}
+
+	if _, err := DeriveSchema(42); err == nil {
+		t.Error("Expected error for non-struct value")
+	}
+}
+
+func TestSchemaJSONRoundTrip(t *testing.T) {
+	schema, err := DeriveSchema(&schemaNode{})
+	if err != nil {
+		t.Fatalf("DeriveSchema failed: %v", err)
+	}
+
+	data, err := schema.JSON()
+	if err != nil {
+		t.Fatalf("JSON failed: %v", err)
+	}
+	var decoded Schema
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, schema) {
+		t.Errorf("Expected %+v after round trip, got %+v", schema, decoded)
+	}
+
+	if err := ValidateAgainstSchema(&schemaNode{Name: "root"}, decoded); err != nil {
+		t.Errorf("Expected decoded schema to validate, got %v", err)
+	}
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	type config struct {
+		Server struct {
+			Port int `yaml:"port"`
+		} `yaml:"server"`
+		Node *schemaNode `yaml:"node"`
+	}
+
+	valid := config{Node: &schemaNode{Name: "root"}}
+	valid.Server.Port = 8080
+
+	tests := []struct {
+		name   string
+		target interface{}
+		schema Schema
+		want   string
+	}{
+		{
+			name:   "valid",
+			target: &valid,
+			schema: Schema{Fields: []FieldSchema{
+				{Path: "server.port", Type: "int", Required: true, Validate: "min=1"},
+				{Path: "node.name", Type: "string", Required: true},
+			}},
+		},
+		{
+			name:   "required",
+			target: &config{},
+			schema: Schema{Fields: []FieldSchema{{Path: "server.port", Type: "int", Required: true}}},
+			want:   "server.port: field is required",
+		},
+		{
+			name:   "rule",
+			target: &valid,
+			schema: Schema{Fields: []FieldSchema{{Path: "server.port", Validate: "max=100"}}},
+			want:   "server.port: failed on the 'max' rule",
+		},
+		{
+			name:   "type mismatch",
+			target: &valid,
+			schema: Schema{Fields: []FieldSchema{{Path: "server.port", Type: "string"}}},
+			want:   "server.port: expected type string, got int",
+		},
+		{
+			name:   "nil pointer",
+			target: &config{},
+			schema: Schema{Fields: []FieldSchema{{Path: "node.name", Required: true}}},
+			want:   "node.name: field is required",
+		},
+		{
+			name:   "missing field",
+			target: &valid,
+			schema: Schema{Fields: []FieldSchema{{Path: "server.host"}}},
+			want:   "server.host: field not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAgainstSchema(tt.target, tt.schema)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
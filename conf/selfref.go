@@ -0,0 +1,129 @@
+package conf
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// selfRefRegex 自引用正则表达式：匹配${self.Path}格式，Path 为点分隔的配置键或Go字段名
+var selfRefRegex = regexp.MustCompile(`\${self\.([^{}]+)}`)
+
+// selfRefResolver 自引用解析器
+type selfRefResolver struct {
+	root     reflect.Value
+	resolved map[string]string
+	visiting []string
+}
+
+// resolveSelfRefs 将字符串字段中的 ${self.Path} 替换为同一配置中对应字段的值
+// 被引用的字段本身包含引用时会先解析该字段，循环引用返回错误
+func resolveSelfRefs(config interface{}) error {
+	v := reflect.ValueOf(config)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	r := &selfRefResolver{
+		root:     v,
+		resolved: make(map[string]string),
+	}
+	return r.walk(v)
+}
+
+// walk 递归处理结构体中的字符串字段
+func (r *selfRefResolver) walk(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath != "" {
+			continue
+		}
+
+		fv := v.Field(i)
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				break
+			}
+			fv = fv.Elem()
+		}
+
+		switch fv.Kind() {
+		case reflect.Struct:
+			if err := r.walk(fv); err != nil {
+				return err
+			}
+		case reflect.String:
+			if !strings.Contains(fv.String(), "${self.") {
+				continue
+			}
+			s, err := r.expand(fv.String())
+			if err != nil {
+				return err
+			}
+			fv.SetString(s)
+		}
+	}
+	return nil
+}
+
+// expand 替换字符串中的全部自引用
+func (r *selfRefResolver) expand(s string) (string, error) {
+	var firstErr error
+	result := selfRefRegex.ReplaceAllStringFunc(s, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		value, err := r.value(selfRefRegex.FindStringSubmatch(match)[1])
+		if err != nil {
+			firstErr = err
+			return match
+		}
+		return value
+	})
+	return result, firstErr
+}
+
+// value 获取被引用字段解析后的值
+func (r *selfRefResolver) value(path string) (string, error) {
+	if value, ok := r.resolved[path]; ok {
+		return value, nil
+	}
+	for i, p := range r.visiting {
+		if p == path {
+			chain := append(append([]string(nil), r.visiting[i:]...), path)
+			return "", fmt.Errorf("circular self reference: %s", strings.Join(chain, " -> "))
+		}
+	}
+
+	field, ok := lookupPath(r.root, path)
+	if !ok {
+		return "", fmt.Errorf("self reference not found: %s", path)
+	}
+	field = reflect.Indirect(field)
+	if !field.IsValid() {
+		return "", nil
+	}
+	if field.Kind() != reflect.String {
+		value := fmt.Sprint(field.Interface())
+		r.resolved[path] = value
+		return value, nil
+	}
+
+	r.visiting = append(r.visiting, path)
+	value, err := r.expand(field.String())
+	r.visiting = r.visiting[:len(r.visiting)-1]
+	if err != nil {
+		return "", err
+	}
+
+	field.SetString(value)
+	r.resolved[path] = value
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package conf
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Source 配置来源，返回配置内容及其格式（yaml、json、toml）
+// 用于从 Consul、etcd、Redis 等非文件来源加载配置
+type Source interface {
+	Read() ([]byte, string, error)
+}
+
+// LoadFromSource 从配置来源加载配置到结构体
+// config 应该是指向结构体的指针
+func LoadFromSource(src Source, config interface{}, opts ...LoadOption) error {
+	content, format, err := src.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read config source: %v", err)
+	}
+
+	ext := strings.ToLower(format)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return loadContent(ext, content, config, opts)
+}
+
+// KVStore 键值存储，cache.ICache 满足该接口
+type KVStore interface {
+	Get(ctx context.Context, key string, value interface{}) error
+}
+
+// DefaultSourceTimeout 默认的配置来源读取超时时间
+const DefaultSourceTimeout = 5 * time.Second
+
+// kvSource 基于键值存储的配置来源
+type kvSource struct {
+	store  KVStore
+	key    string
+	format string
+}
+
+// NewKVSource 创建基于键值存储的配置来源，如 Redis 缓存
+// 配置内容应以字符串形式保存在 key 中，format 为内容格式
+func NewKVSource(store KVStore, key, format string) Source {
+	return &kvSource{
+		store:  store,
+		key:    key,
+		format: format,
+	}
+}
+
+// Read 读取配置内容
+func (s *kvSource) Read() ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultSourceTimeout)
+	defer cancel()
+
+	var content string
+	if err := s.store.Get(ctx, s.key, &content); err != nil {
+		return nil, "", err
+	}
+	return []byte(content), s.format, nil
+}
@@ -0,0 +1,64 @@
+package conf_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ntshibin/core/cache"
+	"github.com/ntshibin/core/conf"
+)
+
+type sourceConfig struct {
+	Server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"server"`
+}
+
+type memorySource struct {
+	data   []byte
+	format string
+}
+
+func (s memorySource) Read() ([]byte, string, error) {
+	return s.data, s.format, nil
+}
+
+func TestLoadFromSource(t *testing.T) {
+	src := memorySource{
+		data:   []byte("server:\n  host: example.com\n  port: 9090\n"),
+		format: "yaml",
+	}
+
+	var config sourceConfig
+	if err := conf.LoadFromSource(src, &config); err != nil {
+		t.Fatalf("LoadFromSource failed: %v", err)
+	}
+	if config.Server.Host != "example.com" || config.Server.Port != 9090 {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+}
+
+func TestLoadFromKVSource(t *testing.T) {
+	store := cache.NewMemoryCache(&cache.BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}, &cache.MemoryCacheConfig{})
+	content := `{"server": {"host": "cache.local", "port": 7070}}`
+	if err := store.Set(context.Background(), "config:app", content, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	var config sourceConfig
+	if err := conf.LoadFromSource(conf.NewKVSource(store, "config:app", "json"), &config); err != nil {
+		t.Fatalf("LoadFromSource failed: %v", err)
+	}
+	if config.Server.Host != "cache.local" || config.Server.Port != 7070 {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	if err := conf.LoadFromSource(conf.NewKVSource(store, "config:missing", "json"), &config); err == nil {
+		t.Error("Expected error for missing key")
+	}
+}
@@ -0,0 +1,53 @@
+package conf
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeInfo 结构体类型的反射元数据，按类型缓存以避免每次加载都重新解析标签
+type typeInfo struct {
+	t reflect.Type
+	// deprecated 带有 deprecated 标签的字段
+	deprecated []deprecatedField
+	// hasDefaults 是否存在带有 default 标签的字段，不存在时加载配置无需生成Schema
+	hasDefaults bool
+	// schema 由结构体标签生成的Schema，首次使用Schema相关功能时生成
+	schema     Schema
+	schemaOnce sync.Once
+}
+
+// typeCache 类型元数据缓存
+var typeCache sync.Map
+
+// cachedTypeInfo 获取结构体类型的反射元数据，首次访问时解析并缓存
+func cachedTypeInfo(t reflect.Type) *typeInfo {
+	if info, ok := typeCache.Load(t); ok {
+		return info.(*typeInfo)
+	}
+
+	info := &typeInfo{
+		t:           t,
+		deprecated:  findDeprecated(t, "", map[reflect.Type]bool{t: true}, nil),
+		hasDefaults: hasDefaultTag(t, map[reflect.Type]bool{t: true}),
+	}
+
+	actual, _ := typeCache.LoadOrStore(t, info)
+	return actual.(*typeInfo)
+}
+
+// getSchema 返回类型的Schema，首次调用时生成
+func (info *typeInfo) getSchema() Schema {
+	info.schemaOnce.Do(func() {
+		deriveFields(info.t, "", map[reflect.Type]bool{info.t: true}, &info.schema)
+	})
+	return info.schema
+}
+
+// ClearTypeCache 清空类型元数据缓存，主要用于测试
+func ClearTypeCache() {
+	typeCache.Range(func(key, _ interface{}) bool {
+		typeCache.Delete(key)
+		return true
+	})
+}
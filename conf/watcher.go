@@ -1,7 +1,10 @@
 package conf
 
 import (
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,10 +15,17 @@ type ConfigWatcher struct {
 	config   interface{}
 	lastMod  time.Time
 	callback func(interface{})
+	structs  []structWatch
 	stop     chan struct{}
 	mu       sync.RWMutex
 }
 
+// structWatch 结构体字段变更订阅
+type structWatch struct {
+	path string
+	fn   func(oldVal, newVal interface{})
+}
+
 // NewConfigWatcher 创建配置监听器
 func NewConfigWatcher(file string, config interface{}, callback func(interface{})) (*ConfigWatcher, error) {
 	watcher := &ConfigWatcher{
@@ -70,11 +80,169 @@ func (w *ConfigWatcher) watch() {
 			if info.ModTime().After(lastMod) {
 				w.mu.Lock()
 				w.lastMod = info.ModTime()
-				if err := LoadConfig(w.file, w.config); err == nil && w.callback != nil {
+				snapshots := w.snapshotStructs()
+				err := LoadConfig(w.file, w.config)
+				if err == nil && w.callback != nil {
 					w.callback(w.config)
 				}
 				w.mu.Unlock()
+
+				if err == nil {
+					w.notifyStructs(snapshots)
+				}
+			}
+		}
+	}
+}
+
+// OnStructChange 订阅嵌套结构体字段的变更
+// fieldPath 为点分隔的配置键（如 "database"），结构体内任一子字段变化时调用 fn，
+// 传入变更前后的结构体值
+func (w *ConfigWatcher) OnStructChange(fieldPath string, fn func(oldVal, newVal interface{})) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := lookupPath(reflect.ValueOf(w.config), fieldPath); !ok {
+		return fmt.Errorf("config field not found: %s", fieldPath)
+	}
+	w.structs = append(w.structs, structWatch{path: fieldPath, fn: fn})
+	return nil
+}
+
+// snapshotStructs 在重新加载前保存被订阅字段的副本
+func (w *ConfigWatcher) snapshotStructs() []interface{} {
+	snapshots := make([]interface{}, len(w.structs))
+	for i, sw := range w.structs {
+		if v, ok := lookupPath(reflect.ValueOf(w.config), sw.path); ok {
+			snapshots[i] = deepCopy(v).Interface()
+		}
+	}
+	return snapshots
+}
+
+// notifyStructs 比较重新加载前后的字段值，通知发生变化的订阅者
+func (w *ConfigWatcher) notifyStructs(snapshots []interface{}) {
+	type change struct {
+		fn       func(oldVal, newVal interface{})
+		old, new interface{}
+	}
+
+	w.mu.RLock()
+	var changes []change
+	for i, sw := range w.structs {
+		if i >= len(snapshots) {
+			break
+		}
+		v, ok := lookupPath(reflect.ValueOf(w.config), sw.path)
+		if !ok {
+			continue
+		}
+		current := deepCopy(v).Interface()
+		if !reflect.DeepEqual(snapshots[i], current) {
+			changes = append(changes, change{fn: sw.fn, old: snapshots[i], new: current})
+		}
+	}
+	w.mu.RUnlock()
+
+	for _, c := range changes {
+		c.fn(c.old, c.new)
+	}
+}
+
+// lookupPath 按点分隔的配置键查找字段，每一段可以是配置键或Go字段名
+func lookupPath(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
 			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		field, ok := findField(v, name)
+		if !ok {
+			return reflect.Value{}, false
+		}
+		v = field
+	}
+	return v, true
+}
+
+// findField 在结构体中查找配置键或Go字段名对应的字段，内联字段会被展开查找
+func findField(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, inline, ok := configFieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+		if inline {
+			fv := reflect.Indirect(v.Field(i))
+			if fv.Kind() == reflect.Struct {
+				if field, found := findField(fv, name); found {
+					return field, true
+				}
+			}
+			continue
+		}
+		if key == name || t.Field(i).Name == name {
+			return v.Field(i), true
 		}
 	}
+	return reflect.Value{}, false
+}
+
+// deepCopy 深拷贝字段值，避免重新加载时复用的map等引用类型影响快照
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cp := reflect.New(v.Type().Elem())
+		cp.Elem().Set(deepCopy(v.Elem()))
+		return cp
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if cp.Field(i).CanSet() {
+				cp.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return cp
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return cp
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return cp
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(deepCopy(v.Elem()))
+		return cp
+	}
+
+	cp := reflect.New(v.Type()).Elem()
+	cp.Set(v)
+	return cp
 }
@@ -0,0 +1,80 @@
+package conf
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type watcherTestConfig struct {
+	Server struct {
+		Port int `yaml:"port"`
+	} `yaml:"server"`
+	Database struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"database"`
+}
+
+func TestConfigWatcherOnStructChange(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string, mod time.Time) {
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+		if err := os.Chtimes(file, mod, mod); err != nil {
+			t.Fatalf("Chtimes failed: %v", err)
+		}
+	}
+	now := time.Now()
+	write("server:\n  port: 8080\ndatabase:\n  host: db1\n  port: 5432\n", now)
+
+	var config watcherTestConfig
+	watcher, err := NewConfigWatcher(file, &config, nil)
+	if err != nil {
+		t.Fatalf("NewConfigWatcher failed: %v", err)
+	}
+	defer watcher.Stop()
+
+	dbChanged := make(chan [2]interface{}, 1)
+	serverChanged := make(chan struct{}, 1)
+	if err := watcher.OnStructChange("database", func(oldVal, newVal interface{}) {
+		dbChanged <- [2]interface{}{oldVal, newVal}
+	}); err != nil {
+		t.Fatalf("OnStructChange failed: %v", err)
+	}
+	if err := watcher.OnStructChange("server", func(oldVal, newVal interface{}) {
+		serverChanged <- struct{}{}
+	}); err != nil {
+		t.Fatalf("OnStructChange failed: %v", err)
+	}
+	if err := watcher.OnStructChange("missing", func(oldVal, newVal interface{}) {}); err == nil {
+		t.Error("Expected error for unknown field path")
+	}
+
+	write("server:\n  port: 8080\ndatabase:\n  host: db2\n  port: 5432\n", now.Add(2*time.Second))
+
+	select {
+	case change := <-dbChanged:
+		oldVal := change[0].(struct {
+			Host string `yaml:"host"`
+			Port int    `yaml:"port"`
+		})
+		newVal := change[1].(struct {
+			Host string `yaml:"host"`
+			Port int    `yaml:"port"`
+		})
+		if oldVal.Host != "db1" || newVal.Host != "db2" {
+			t.Errorf("Expected db1 -> db2, got %s -> %s", oldVal.Host, newVal.Host)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected database change callback")
+	}
+
+	select {
+	case <-serverChanged:
+		t.Error("Expected no callback for unchanged server config")
+	default:
+	}
+}
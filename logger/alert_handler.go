@@ -0,0 +1,67 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultAlertTimeout 告警请求的默认超时时间
+const DefaultAlertTimeout = 2 * time.Second
+
+// AlertHandler 告警处理器
+// 将达到告警级别的日志同步POST到Webhook，保证Fatal日志在进程退出前送达
+type AlertHandler struct {
+	*BaseHandler
+	webhook string
+	client  *http.Client
+}
+
+// NewAlertHandler 创建告警处理器，level 为触发告警的最低级别
+func NewAlertHandler(formatter Formatter, level LogLevel, webhook string, timeout time.Duration) (*AlertHandler, error) {
+	if webhook == "" {
+		return nil, fmt.Errorf("告警地址不能为空")
+	}
+	if _, err := url.Parse(webhook); err != nil {
+		return nil, fmt.Errorf("无效的告警地址: %v", err)
+	}
+	if timeout <= 0 {
+		timeout = DefaultAlertTimeout
+	}
+
+	return &AlertHandler{
+		BaseHandler: NewBaseHandler(formatter, level),
+		webhook:     webhook,
+		client:      &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// ShouldHandle 是否应该发送告警，不受上下文级别覆盖影响
+func (h *AlertHandler) ShouldHandle(event LogEvent) bool {
+	return event.Level >= h.level
+}
+
+// Handle 处理日志事件，同步发送告警
+func (h *AlertHandler) Handle(event LogEvent) error {
+	if !h.ShouldHandle(event) {
+		return nil
+	}
+
+	data, err := h.Format(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Post(h.webhook, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("发送告警失败: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("发送告警失败，状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
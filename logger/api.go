@@ -12,6 +12,11 @@ var (
 	shutdownFuncs []func() error
 )
 
+// Trace 输出Trace级别日志
+func Trace(msg string) {
+	GetDefaultLogger().Trace(msg)
+}
+
 // Debug 输出Debug级别日志
 func Debug(msg string) {
 	GetDefaultLogger().Debug(msg)
@@ -70,6 +75,14 @@ func Sync() error {
 	return nil
 }
 
+// GetHandlerMetrics 获取默认日志记录器中所有异步处理器的汇总指标
+func GetHandlerMetrics() AsyncHandlerMetrics {
+	if logger, ok := GetDefaultLogger().(*StandardLogger); ok {
+		return logger.GetHandlerMetrics()
+	}
+	return AsyncHandlerMetrics{}
+}
+
 // InitLifecycle 初始化生命周期管理
 func InitLifecycle() {
 	lifecycleOnce.Do(func() {
@@ -205,13 +218,8 @@ func LoggerFromContext(ctx context.Context) LoggerInterface {
 		return GetDefaultLogger()
 	}
 
-	// 获取上下文中的日志上下文
-	logCtx := LogContextFromContext(ctx)
-	if logCtx != nil {
-		return GetDefaultLogger().WithContext(ctx)
-	}
-
-	return GetDefaultLogger()
+	// 日志上下文和 trace_id 等上下文值由 WithContext 提取
+	return GetDefaultLogger().WithContext(ctx)
 }
 
 // CreateTraceContext 创建带有追踪的上下文
@@ -1,10 +1,24 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 )
 
+// AsyncHandlerMetrics 异步处理器运行指标
+type AsyncHandlerMetrics struct {
+	// QueueDepth 队列中等待处理的日志条数
+	QueueDepth int
+	// TotalDropped 因队列已满被丢弃的日志条数
+	TotalDropped uint64
+	// TotalProcessed 已交给内部处理器处理的日志条数
+	TotalProcessed uint64
+	// BatchCount 工作协程被唤醒后连续处理直到队列为空的批次数
+	BatchCount uint64
+}
+
 // AsyncHandler 异步处理器
 type AsyncHandler struct {
 	handler   Handler
@@ -13,6 +27,9 @@ type AsyncHandler struct {
 	closeOnce sync.Once
 	closed    bool
 	mu        sync.RWMutex
+	dropped   atomic.Uint64
+	processed atomic.Uint64
+	batches   atomic.Uint64
 }
 
 // NewAsyncHandler 创建异步处理器
@@ -38,24 +55,56 @@ func (h *AsyncHandler) worker() {
 	defer h.wg.Done()
 
 	for event := range h.queue {
-		_ = h.handler.Handle(event)
+		h.process(event)
+
+		// 连续处理已排队的事件，直到队列为空，作为一个批次
+	drain:
+		for {
+			select {
+			case event, ok := <-h.queue:
+				if !ok {
+					break drain
+				}
+				h.process(event)
+			default:
+				break drain
+			}
+		}
+		h.batches.Add(1)
+	}
+}
+
+// process 将事件交给内部处理器处理
+func (h *AsyncHandler) process(event LogEvent) {
+	_ = h.handler.Handle(event)
+	h.processed.Add(1)
+}
+
+// Metrics 返回处理器的运行指标
+func (h *AsyncHandler) Metrics() AsyncHandlerMetrics {
+	return AsyncHandlerMetrics{
+		QueueDepth:     len(h.queue),
+		TotalDropped:   h.dropped.Load(),
+		TotalProcessed: h.processed.Load(),
+		BatchCount:     h.batches.Load(),
 	}
 }
 
 // Handle 处理日志事件
 func (h *AsyncHandler) Handle(event LogEvent) error {
+	// 发送期间持有读锁，避免与关闭并发时向已关闭的队列发送
 	h.mu.RLock()
+	defer h.mu.RUnlock()
 	if h.closed {
-		h.mu.RUnlock()
 		return fmt.Errorf("handler已关闭")
 	}
-	h.mu.RUnlock()
 
 	// 非阻塞发送，避免队列满导致应用程序阻塞
 	select {
 	case h.queue <- event:
 		return nil
 	default:
+		h.dropped.Add(1)
 		return fmt.Errorf("队列已满，丢弃事件")
 	}
 }
@@ -70,22 +119,38 @@ func (h *AsyncHandler) ShouldHandle(event LogEvent) bool {
 	return h.handler.ShouldHandle(event)
 }
 
-// Close 关闭处理器
+// Close 关闭处理器，等待队列中的所有事件处理完成
 func (h *AsyncHandler) Close() error {
-	var err error
+	return h.CloseWithContext(context.Background())
+}
+
+// CloseWithContext 关闭处理器，在 ctx 结束前等待队列中的事件处理完成
+// 超时后返回错误，剩余事件仍会在后台继续处理，处理完成后关闭内部处理器
+func (h *AsyncHandler) CloseWithContext(ctx context.Context) error {
+	var done chan error
 	h.closeOnce.Do(func() {
 		h.mu.Lock()
 		h.closed = true
 		close(h.queue)
 		h.mu.Unlock()
 
-		// 等待所有事件处理完成
-		h.wg.Wait()
-
-		// 关闭内部处理器
-		err = h.handler.Close()
+		// 等待所有事件处理完成后关闭内部处理器
+		done = make(chan error, 1)
+		go func() {
+			h.wg.Wait()
+			done <- h.handler.Close()
+		}()
 	})
-	return err
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("异步队列未能及时处理完成，剩余 %d 条日志: %w", len(h.queue), ctx.Err())
+	}
 }
 
 // Sync 同步等待所有事件处理完成
@@ -114,7 +179,7 @@ func (h *AsyncHandler) Sync() error {
 				close(done)
 				return
 			}
-			_ = h.handler.Handle(event)
+			h.process(event)
 		}
 	}()
 
@@ -0,0 +1,219 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TailStore 日志尾部存储，cache.ICache 满足该接口
+// 读取失败时通过 Has 区分日志列表尚不存在与存储本身出错
+type TailStore interface {
+	Get(ctx context.Context, key string, value interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Has(ctx context.Context, key string) (bool, error)
+}
+
+// CacheTailConfig 缓存日志尾部配置
+type CacheTailConfig struct {
+	// Key 缓存键，通常使用服务名，如 logs:order-service
+	Key string `yaml:"key" json:"key"`
+	// MaxLen 保留的最大日志条数
+	MaxLen int `yaml:"max_len" json:"max_len"`
+	// TTL 缓存过期时间，0 时使用 DefaultCacheTailConfig 的过期时间
+	TTL time.Duration `yaml:"ttl" json:"ttl"`
+	// BatchSize 批量写入的日志条数，积累到该数量后一次写入缓存，小于等于 1 时每条日志立即写入
+	BatchSize int `yaml:"batch_size" json:"batch_size"`
+	// FlushInterval 批量写入时的定时刷新间隔，0 表示只在积累满一批或关闭时写入
+	FlushInterval time.Duration `yaml:"flush_interval" json:"flush_interval"`
+}
+
+// DefaultCacheTailConfig 默认缓存日志尾部配置
+var DefaultCacheTailConfig = CacheTailConfig{
+	Key:    "logs:default",
+	MaxLen: 1000,
+	TTL:    time.Hour * 24,
+}
+
+// CacheTailHandler 缓存日志尾部处理器
+// 将格式化后的日志追加到共享缓存中的定长列表，便于跨实例、跨重启查询最近的日志
+type CacheTailHandler struct {
+	*BaseHandler
+	store   TailStore
+	config  CacheTailConfig
+	pending []string
+	mu      sync.Mutex
+	ticker  *time.Ticker
+	done    chan struct{}
+	closed  bool
+}
+
+// NewCacheTailHandler 创建缓存日志尾部处理器
+func NewCacheTailHandler(formatter Formatter, level LogLevel, store TailStore, config CacheTailConfig) (*CacheTailHandler, error) {
+	if store == nil {
+		return nil, fmt.Errorf("日志存储不能为空")
+	}
+	if config.Key == "" {
+		config.Key = DefaultCacheTailConfig.Key
+	}
+	if config.MaxLen <= 0 {
+		config.MaxLen = DefaultCacheTailConfig.MaxLen
+	}
+	if config.TTL == 0 {
+		config.TTL = DefaultCacheTailConfig.TTL
+	}
+
+	h := &CacheTailHandler{
+		BaseHandler: NewBaseHandler(formatter, level),
+		store:       store,
+		config:      config,
+		done:        make(chan struct{}),
+	}
+
+	// 启动定时刷新
+	if config.BatchSize > 1 && config.FlushInterval > 0 {
+		h.ticker = time.NewTicker(config.FlushInterval)
+		go h.scheduleFlush()
+	}
+
+	return h, nil
+}
+
+// scheduleFlush 定时将缓冲的日志写入缓存
+func (h *CacheTailHandler) scheduleFlush() {
+	for {
+		select {
+		case <-h.ticker.C:
+			_ = h.Flush()
+		case <-h.done:
+			return
+		}
+	}
+}
+
+// Handle 处理日志事件
+// 列表以读取-追加-写回的方式更新，同一进程内串行执行，多个实例同时写入时可能丢失少量日志
+func (h *CacheTailHandler) Handle(event LogEvent) error {
+	if !h.ShouldHandle(event) {
+		return nil
+	}
+
+	data, err := h.Format(event)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pending = append(h.pending, strings.TrimSuffix(string(data), "\n"))
+	if len(h.pending) < h.config.BatchSize {
+		return nil
+	}
+	return h.flushLocked()
+}
+
+// Flush 将缓冲的日志写入缓存
+func (h *CacheTailHandler) Flush() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.flushLocked()
+}
+
+// flushLocked 将缓冲的日志追加到缓存列表，调用方需持有锁
+// 写入失败时保留缓冲的日志等待下次写入，缓冲最多保留 MaxLen 条
+func (h *CacheTailHandler) flushLocked() error {
+	if len(h.pending) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	entries, err := readTail(ctx, h.store, h.config.Key)
+	if err != nil {
+		// 日志列表不存在时从空列表开始，其他错误不能覆盖已有的日志
+		exists, herr := h.store.Has(ctx, h.config.Key)
+		if herr != nil || exists {
+			h.trimPending()
+			return fmt.Errorf("读取日志缓存失败: %v", err)
+		}
+		entries = nil
+	}
+
+	entries = append(entries, h.pending...)
+	if len(entries) > h.config.MaxLen {
+		entries = entries[len(entries)-h.config.MaxLen:]
+	}
+
+	if err := h.store.Set(ctx, h.config.Key, entries, h.config.TTL); err != nil {
+		h.trimPending()
+		return fmt.Errorf("写入日志缓存失败: %v", err)
+	}
+	h.pending = h.pending[:0]
+	return nil
+}
+
+// trimPending 只保留最近的 MaxLen 条缓冲日志，避免存储持续不可用时缓冲无限增长
+func (h *CacheTailHandler) trimPending() {
+	if len(h.pending) > h.config.MaxLen {
+		h.pending = append(h.pending[:0], h.pending[len(h.pending)-h.config.MaxLen:]...)
+	}
+}
+
+// Close 关闭处理器，写入剩余的缓冲日志
+func (h *CacheTailHandler) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	h.mu.Unlock()
+
+	if h.ticker != nil {
+		h.ticker.Stop()
+	}
+	close(h.done)
+	return h.Flush()
+}
+
+// Tail 获取最近的 n 条日志，按时间顺序排列，不包含尚未写入缓存的缓冲日志
+func (h *CacheTailHandler) Tail(ctx context.Context, n int) ([]string, error) {
+	return ReadCacheTail(ctx, h.store, h.config.Key, n)
+}
+
+// ReadCacheTail 从缓存中读取最近的 n 条日志，n <= 0 时返回全部
+func ReadCacheTail(ctx context.Context, store TailStore, key string, n int) ([]string, error) {
+	entries, err := readTail(ctx, store, key)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, nil
+}
+
+// readTail 读取缓存中的日志列表
+// 不同缓存实现解码后的类型不同，因此先读取为 interface{} 再转换
+func readTail(ctx context.Context, store TailStore, key string) ([]string, error) {
+	var raw interface{}
+	if err := store.Get(ctx, key, &raw); err != nil {
+		return nil, err
+	}
+
+	switch v := raw.(type) {
+	case []string:
+		return append([]string(nil), v...), nil
+	case []interface{}:
+		entries := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				entries = append(entries, s)
+			}
+		}
+		return entries, nil
+	}
+	return nil, fmt.Errorf("日志缓存格式无效: %T", raw)
+}
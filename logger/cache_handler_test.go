@@ -0,0 +1,181 @@
+package logger_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ntshibin/core/cache"
+	"github.com/ntshibin/core/logger"
+)
+
+func TestCacheTailHandler(t *testing.T) {
+	store := cache.NewMemoryCache(&cache.BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}, &cache.MemoryCacheConfig{})
+
+	handler, err := logger.NewCacheTailHandler(logger.NewJSONFormatter(), logger.InfoLevel, store, logger.CacheTailConfig{
+		Key:    "logs:order-service",
+		MaxLen: 5,
+		TTL:    time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("NewCacheTailHandler failed: %v", err)
+	}
+	log := logger.NewStandardLogger("test", logger.InfoLevel, handler)
+
+	for i := 0; i < 8; i++ {
+		log.Info(fmt.Sprintf("entry %d", i))
+	}
+
+	entries, err := handler.Tail(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Tail failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	for i, want := range []string{"entry 5", "entry 6", "entry 7"} {
+		if !strings.Contains(entries[i], want) {
+			t.Errorf("Expected entry %d to contain %q, got %s", i, want, entries[i])
+		}
+	}
+
+	// 列表长度被限制为 MaxLen
+	all, err := logger.ReadCacheTail(context.Background(), store, "logs:order-service", 0)
+	if err != nil {
+		t.Fatalf("ReadCacheTail failed: %v", err)
+	}
+	if len(all) != 5 {
+		t.Errorf("Expected list trimmed to 5 entries, got %d", len(all))
+	}
+}
+
+func TestCacheTailHandlerBatching(t *testing.T) {
+	store := cache.NewMemoryCache(&cache.BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}, &cache.MemoryCacheConfig{})
+
+	handler, err := logger.NewCacheTailHandler(logger.NewJSONFormatter(), logger.InfoLevel, store, logger.CacheTailConfig{
+		Key:       "logs:batch",
+		MaxLen:    4,
+		TTL:       time.Minute,
+		BatchSize: 3,
+	})
+	if err != nil {
+		t.Fatalf("NewCacheTailHandler failed: %v", err)
+	}
+	log := logger.NewStandardLogger("test", logger.InfoLevel, handler)
+	ctx := context.Background()
+
+	log.Info("entry 0")
+	log.Info("entry 1")
+	if _, err := handler.Tail(ctx, 0); err != cache.ErrNotFound {
+		t.Errorf("Expected nothing written before a full batch, got %v", err)
+	}
+
+	// 满一批后一次写入
+	log.Info("entry 2")
+	entries, err := handler.Tail(ctx, 0)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("Expected 3 entries after a full batch, got %d (%v)", len(entries), err)
+	}
+
+	// 关闭时写入剩余日志，列表长度仍受 MaxLen 限制
+	log.Info("entry 3")
+	log.Info("entry 4")
+	if err := handler.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	entries, err = handler.Tail(ctx, 0)
+	if err != nil || len(entries) != 4 {
+		t.Fatalf("Expected 4 entries after close, got %d (%v)", len(entries), err)
+	}
+	for i, want := range []string{"entry 1", "entry 2", "entry 3", "entry 4"} {
+		if !strings.Contains(entries[i], want) {
+			t.Errorf("Expected entry %d to contain %q, got %s", i, want, entries[i])
+		}
+	}
+}
+
+// flakyTailStore 可注入读写错误并记录写入过期时间的日志存储
+type flakyTailStore struct {
+	logger.TailStore
+	getErr error
+	setErr error
+	ttl    time.Duration
+}
+
+func (s *flakyTailStore) Get(ctx context.Context, key string, value interface{}) error {
+	if s.getErr != nil {
+		return s.getErr
+	}
+	return s.TailStore.Get(ctx, key, value)
+}
+
+func (s *flakyTailStore) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if s.setErr != nil {
+		return s.setErr
+	}
+	s.ttl = ttl
+	return s.TailStore.Set(ctx, key, value, ttl)
+}
+
+func TestCacheTailHandlerStoreErrors(t *testing.T) {
+	store := &flakyTailStore{TailStore: cache.NewMemoryCache(&cache.BaseConfig{
+		MaxSize:         100,
+		CleanupInterval: 60,
+	}, &cache.MemoryCacheConfig{})}
+
+	handler, err := logger.NewCacheTailHandler(logger.NewJSONFormatter(), logger.InfoLevel, store, logger.CacheTailConfig{
+		Key:       "logs:flaky",
+		BatchSize: 10,
+	})
+	if err != nil {
+		t.Fatalf("NewCacheTailHandler failed: %v", err)
+	}
+	log := logger.NewStandardLogger("test", logger.InfoLevel, handler)
+	ctx := context.Background()
+
+	// 未设置 TTL 时使用默认过期时间
+	log.Info("entry 0")
+	if err := handler.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	if store.ttl != logger.DefaultCacheTailConfig.TTL {
+		t.Errorf("Expected default TTL %v, got %v", logger.DefaultCacheTailConfig.TTL, store.ttl)
+	}
+
+	// 写入失败时保留缓冲的日志
+	store.setErr = errors.New("store unavailable")
+	log.Info("entry 1")
+	if err := handler.Flush(); err == nil {
+		t.Error("Expected error when the store rejects writes")
+	}
+	store.setErr = nil
+
+	// 读取失败时不能用缓冲的日志覆盖已有列表
+	store.getErr = errors.New("store unavailable")
+	if err := handler.Flush(); err == nil {
+		t.Error("Expected error when the store cannot be read")
+	}
+	store.getErr = nil
+
+	if err := handler.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+	entries, err := handler.Tail(ctx, 0)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected 2 entries after recovery, got %d (%v)", len(entries), err)
+	}
+	for i, want := range []string{"entry 0", "entry 1"} {
+		if !strings.Contains(entries[i], want) {
+			t.Errorf("Expected entry %d to contain %q, got %s", i, want, entries[i])
+		}
+	}
+}
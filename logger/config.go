@@ -1,10 +1,23 @@
 package logger
 
+import (
+	"fmt"
+
+	"github.com/ntshibin/core/conf"
+)
+
+func init() {
+	// 配置加载过程中的警告通过默认日志记录器输出
+	conf.SetWarnFunc(func(msg string) {
+		Warn(msg)
+	})
+}
+
 // LoggerConfig 日志配置结构体
 type LoggerConfig struct {
 	// 日志记录器名称
 	Name string `yaml:"name" json:"name"`
-	// 日志级别: debug, info, warn, error, fatal
+	// 日志级别: trace, debug, info, warn, error, fatal
 	Level string `yaml:"level" json:"level"`
 	// 输出格式: json, text
 	Encoding string `yaml:"encoding" json:"encoding"`
@@ -36,6 +49,24 @@ type LoggerConfig struct {
 
 	// 调用链跟踪配置
 	EnableTrace bool `yaml:"enable_trace" json:"enable_trace"`
+
+	// 日志采样配置，相同日志在采样周期内超过上限后被丢弃
+	EnableSampling bool           `yaml:"enable_sampling" json:"enable_sampling"`
+	Sampling       SamplingConfig `yaml:"sampling" json:"sampling"`
+
+	// 按调用者屏蔽日志，按路径段匹配调用者源文件的路径，如 vendor/go.etcd.io，也可以匹配 file.go:line 的前缀
+	SilenceCallers []string `yaml:"silence_callers" json:"silence_callers"`
+
+	// 告警配置，达到告警级别的日志会同步发送到Webhook
+	AlertWebhook string `yaml:"alert_webhook" json:"alert_webhook"`
+	// 告警级别: error, fatal，默认为 fatal
+	AlertLevel string `yaml:"alert_level" json:"alert_level"`
+
+	// 额外启用的处理器，名称需先通过 RegisterHandler 注册
+	Handlers []string `yaml:"handlers" json:"handlers"`
+
+	// 按模块设置日志级别，键为包路径前缀，如 github.com/app/order: debug
+	ModuleLevels map[string]string `yaml:"module_levels" json:"module_levels"`
 }
 
 // DefaultLoggerConfig 默认日志配置
@@ -56,61 +87,68 @@ var DefaultLoggerConfig = LoggerConfig{
 	EnableMemory:   false,
 	Memory:         DefaultMemoryConfig,
 	EnableTrace:    false,
+	EnableSampling: false,
+	Sampling:       DefaultSamplingConfig,
 }
 
 // LoadConfig 初始化日志系统
 func LoadConfig(config LoggerConfig) error {
-	// 解析日志级别
-	level, err := ParseLevel(config.Level)
+	logger, err := newLoggerFromConfig(config)
 	if err != nil {
 		return err
 	}
 
-	// 创建默认处理器
-	var handlers []Handler
+	// 替换默认日志记录器
+	manager := GetLogManager()
+	manager.mu.Lock()
+	manager.loggers["default"] = logger
+	manager.mu.Unlock()
 
-	// 添加控制台处理器
-	if config.EnableConsole {
-		var formatter Formatter
-		if config.Encoding == "json" {
-			formatter = NewJSONFormatter()
-		} else {
-			formatter = NewTextFormatter()
-		}
-		handlers = append(handlers, NewConsoleHandler(formatter, level))
+	return nil
+}
+
+// newLoggerFromConfig 根据配置创建日志记录器，并应用异步模式、模块级别等全局设置
+func newLoggerFromConfig(config LoggerConfig) (*StandardLogger, error) {
+	// 解析日志级别
+	level, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, err
 	}
 
-	// 添加文件处理器
-	if config.EnableFile && !config.EnableRotate {
-		handler, err := NewFileHandler(NewJSONFormatter(), level, config.FilePath)
+	// 解析模块日志级别
+	moduleLevels := make(map[string]LogLevel, len(config.ModuleLevels))
+	for prefix, name := range config.ModuleLevels {
+		moduleLevel, err := ParseLevel(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		handlers = append(handlers, handler)
+		moduleLevels[prefix] = moduleLevel
 	}
 
-	// 添加轮转文件处理器
-	if config.EnableRotate {
-		handler, err := NewRotateFileHandler(NewJSONFormatter(), level, config.Rotate)
-		if err != nil {
-			return err
+	// 根据配置创建处理器，处理器级别取日志级别和模块级别中最低者，模块级别由记录器过滤
+	handlerLevel := level
+	for _, moduleLevel := range moduleLevels {
+		if moduleLevel < handlerLevel {
+			handlerLevel = moduleLevel
 		}
-		handlers = append(handlers, handler)
+	}
+	handlers, err := buildHandlers(config, handlerLevel)
+	if err != nil {
+		return nil, err
 	}
 
-	// 添加远程日志处理器
-	if config.EnableRemote {
-		handler, err := NewRemoteHandler(NewJSONFormatter(), level, config.Remote)
-		if err != nil {
-			return err
+	// 按调用者屏蔽日志
+	if len(config.SilenceCallers) > 0 {
+		for i, handler := range handlers {
+			handlers[i] = NewCallerSilenceHandler(handler, config.SilenceCallers)
 		}
-		handlers = append(handlers, handler)
 	}
 
-	// 添加内存日志处理器
-	if config.EnableMemory {
-		handler := NewMemoryHandler(NewJSONFormatter(), level, config.Memory)
-		handlers = append(handlers, handler)
+	// 对高频重复日志进行采样
+	if config.EnableSampling {
+		for i, handler := range handlers {
+			handlers[i] = NewSamplingHandler(handler, config.Sampling)
+		}
 	}
 
 	// 根据异步配置处理处理器
@@ -121,16 +159,28 @@ func LoadConfig(config LoggerConfig) error {
 		SetAsyncQueueSize(config.AsyncQueueSize)
 	}
 
+	for prefix, moduleLevel := range moduleLevels {
+		SetModuleLevel(prefix, moduleLevel)
+	}
+
 	// 创建日志记录器
-	logger := NewStandardLogger(config.Name, level, handlers...)
+	return NewStandardLogger(config.Name, level, handlers...), nil
+}
 
-	// 替换默认日志记录器
-	manager := GetLogManager()
-	manager.mu.Lock()
-	manager.loggers["default"] = logger
-	manager.mu.Unlock()
+// ConfigureFromStruct 根据声明式配置初始化日志系统
+// 内置处理器由 Enable* 开关控制，其他已注册的处理器通过 Handlers 按名称启用
+func ConfigureFromStruct(config LoggerConfig) error {
+	return LoadConfig(config)
+}
 
-	return nil
+// ConfigureFromFile 从配置文件初始化日志系统
+// 按扩展名解析 YAML、JSON 或 TOML 文件，支持与 conf 包相同的环境变量替换，文件中未设置的字段使用 DefaultLoggerConfig 中的默认值
+func ConfigureFromFile(filename string, opts ...conf.LoadOption) error {
+	config := DefaultLoggerConfig
+	if err := conf.LoadConfig(filename, &config, opts...); err != nil {
+		return fmt.Errorf("加载日志配置失败: %w", err)
+	}
+	return LoadConfig(config)
 }
 
 // InitWithFileLog 初始化日志系统并启用文件日志
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigureFromStruct(t *testing.T) {
+	manager := GetLogManager()
+	manager.mu.RLock()
+	original := manager.loggers["default"]
+	manager.mu.RUnlock()
+	defer func() {
+		manager.mu.Lock()
+		manager.loggers["default"] = original
+		manager.mu.Unlock()
+	}()
+
+	var custom *MemoryHandler
+	RegisterHandler("test-memory", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		custom = NewMemoryHandler(NewJSONFormatter(), level, DefaultMemoryConfig)
+		return custom, nil
+	})
+
+	config := DefaultLoggerConfig
+	config.EnableConsole = true
+	config.EnableRotate = true
+	config.Rotate.FilePath = filepath.Join(t.TempDir(), "app.log")
+	config.Handlers = []string{"test-memory"}
+	if err := ConfigureFromStruct(config); err != nil {
+		t.Fatalf("ConfigureFromStruct failed: %v", err)
+	}
+
+	logger, ok := GetDefaultLogger().(*StandardLogger)
+	if !ok {
+		t.Fatal("Expected default logger to be a StandardLogger")
+	}
+	defer logger.Close()
+
+	if len(logger.handlers) != 3 {
+		t.Fatalf("Expected 3 handlers, got %d", len(logger.handlers))
+	}
+	if _, ok := logger.handlers[0].(*ConsoleHandler); !ok {
+		t.Errorf("Expected ConsoleHandler first, got %T", logger.handlers[0])
+	}
+	if _, ok := logger.handlers[1].(*RotateFileHandler); !ok {
+		t.Errorf("Expected RotateFileHandler second, got %T", logger.handlers[1])
+	}
+	if logger.handlers[2] != custom {
+		t.Errorf("Expected registered handler last, got %T", logger.handlers[2])
+	}
+
+	config.Handlers = []string{"missing"}
+	if err := ConfigureFromStruct(config); err == nil {
+		t.Error("Expected error for unregistered handler")
+	}
+}
+
+func TestConfigureFromFile(t *testing.T) {
+	manager := GetLogManager()
+	manager.mu.RLock()
+	original := manager.loggers["default"]
+	manager.mu.RUnlock()
+	defer func() {
+		manager.mu.Lock()
+		manager.loggers["default"] = original
+		manager.mu.Unlock()
+	}()
+
+	dir := t.TempDir()
+	t.Setenv("TEST_LOG_LEVEL", "warn")
+	file := filepath.Join(dir, "logger.yaml")
+	content := "name: from-file\nlevel: ${TEST_LOG_LEVEL}\nenable_console: false\nenable_memory: true\n"
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if err := ConfigureFromFile(file); err != nil {
+		t.Fatalf("ConfigureFromFile failed: %v", err)
+	}
+
+	logger, ok := GetDefaultLogger().(*StandardLogger)
+	if !ok {
+		t.Fatal("Expected default logger to be a StandardLogger")
+	}
+	defer logger.Close()
+
+	if logger.GetLevel() != WarnLevel {
+		t.Errorf("Expected level from environment, got %v", logger.GetLevel())
+	}
+	if len(logger.handlers) != 1 {
+		t.Fatalf("Expected only the memory handler, got %d handlers", len(logger.handlers))
+	}
+	if _, ok := logger.handlers[0].(*MemoryHandler); !ok {
+		t.Errorf("Expected MemoryHandler, got %T", logger.handlers[0])
+	}
+
+	if err := ConfigureFromFile(filepath.Join(dir, "missing.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected wrapped fs.ErrNotExist, got %v", err)
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte("{"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if err := ConfigureFromFile(bad); err == nil {
+		t.Error("Expected error for malformed config")
+	}
+}
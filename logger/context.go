@@ -20,7 +20,8 @@ const (
 // NewContext 创建新的日志上下文
 func NewContext() *LogContext {
 	return &LogContext{
-		Tags: make(map[string]string),
+		Tags:   make(map[string]string),
+		Fields: make(map[string]interface{}),
 	}
 }
 
@@ -42,31 +43,48 @@ func WithLogContext(ctx context.Context, logCtx *LogContext) context.Context {
 
 // WithTag 添加标签
 func (c *LogContext) WithTag(key, value string) *LogContext {
-	newCtx := &LogContext{
-		TraceID:  c.TraceID,
-		SpanID:   c.SpanID,
-		ParentID: c.ParentID,
-		Tags:     make(map[string]string),
-	}
-
-	// 复制现有标签
-	for k, v := range c.Tags {
-		newCtx.Tags[k] = v
-	}
-
-	// 添加新标签
+	newCtx := c.clone()
 	newCtx.Tags[key] = value
-
 	return newCtx
 }
 
 // WithTrace 添加追踪ID
 func (c *LogContext) WithTrace(traceID string) *LogContext {
+	newCtx := c.clone()
+	newCtx.TraceID = traceID
+	return newCtx
+}
+
+// WithSpan 添加跨度ID
+func (c *LogContext) WithSpan(spanID string) *LogContext {
+	newCtx := c.clone()
+	newCtx.SpanID = spanID
+	return newCtx
+}
+
+// WithParent 添加父跨度ID
+func (c *LogContext) WithParent(parentID string) *LogContext {
+	newCtx := c.clone()
+	newCtx.ParentID = parentID
+	return newCtx
+}
+
+// WithField 添加上下文字段
+func (c *LogContext) WithField(key string, value interface{}) *LogContext {
+	newCtx := c.clone()
+	newCtx.Fields[key] = value
+	return newCtx
+}
+
+// clone 复制日志上下文，包括标签和字段
+func (c *LogContext) clone() *LogContext {
 	newCtx := &LogContext{
-		TraceID:  traceID,
-		SpanID:   c.SpanID,
-		ParentID: c.ParentID,
-		Tags:     make(map[string]string),
+		TraceID:       c.TraceID,
+		SpanID:        c.SpanID,
+		ParentID:      c.ParentID,
+		Tags:          make(map[string]string, len(c.Tags)),
+		Fields:        make(map[string]interface{}, len(c.Fields)),
+		LevelOverride: c.LevelOverride,
 	}
 
 	// 复制现有标签
@@ -74,41 +92,101 @@ func (c *LogContext) WithTrace(traceID string) *LogContext {
 		newCtx.Tags[k] = v
 	}
 
+	// 复制现有字段
+	for k, v := range c.Fields {
+		newCtx.Fields[k] = v
+	}
+
 	return newCtx
 }
 
-// WithSpan 添加跨度ID
-func (c *LogContext) WithSpan(spanID string) *LogContext {
-	newCtx := &LogContext{
-		TraceID:  c.TraceID,
-		SpanID:   spanID,
-		ParentID: c.ParentID,
-		Tags:     make(map[string]string),
+// AddContextField 向context中的日志上下文添加字段，返回新的context
+// 之后通过 WithContext 从该context派生的日志记录器，每条日志都会带上这些字段
+func AddContextField(ctx context.Context, key string, value interface{}) context.Context {
+	logCtx := LogContextFromContext(ctx)
+	if logCtx == nil {
+		logCtx = NewContext()
+	}
+	return WithLogContext(ctx, logCtx.WithField(key, value))
+}
+
+// WithLevelOverride 为context设置请求级别的日志级别覆盖，返回新的context
+// 之后通过 WithContext 从该context派生的日志记录器按该级别过滤日志，不影响其他请求
+func WithLevelOverride(ctx context.Context, level LogLevel) context.Context {
+	logCtx := LogContextFromContext(ctx)
+	if logCtx == nil {
+		logCtx = NewContext()
 	}
+	newCtx := logCtx.clone()
+	newCtx.LevelOverride = &level
+	return WithLogContext(ctx, newCtx)
+}
 
-	// 复制现有标签
-	for k, v := range c.Tags {
-		newCtx.Tags[k] = v
+// LevelOverrideFromContext 获取context中的日志级别覆盖
+func LevelOverrideFromContext(ctx context.Context) (LogLevel, bool) {
+	logCtx := LogContextFromContext(ctx)
+	if logCtx == nil || logCtx.LevelOverride == nil {
+		return 0, false
 	}
+	return *logCtx.LevelOverride, true
+}
 
-	return newCtx
+// ContextExtractor 从 context 中提取日志字段，WithContext 会将返回的字段添加到日志中
+type ContextExtractor func(ctx context.Context) map[string]interface{}
+
+// StandardContextKeys 默认从 context 中提取的键，值通过 context.WithValue(ctx, "trace_id", id) 等方式设置
+var StandardContextKeys = []string{"trace_id", "span_id", "request_id"}
+
+// contextExtractors 已注册的上下文字段提取器
+var contextExtractors struct {
+	list []ContextExtractor
+	mu   sync.RWMutex
 }
 
-// WithParent 添加父跨度ID
-func (c *LogContext) WithParent(parentID string) *LogContext {
-	newCtx := &LogContext{
-		TraceID:  c.TraceID,
-		SpanID:   c.SpanID,
-		ParentID: parentID,
-		Tags:     make(map[string]string),
+// RegisterContextExtractor 注册上下文字段提取器，用于从 OpenTelemetry 等自定义上下文中提取字段
+// 提取器按注册顺序执行，同名字段以后执行的为准，日志上下文中通过 AddContextField 设置的字段优先
+func RegisterContextExtractor(extractor ContextExtractor) {
+	if extractor == nil {
+		return
+	}
+	contextExtractors.mu.Lock()
+	defer contextExtractors.mu.Unlock()
+	contextExtractors.list = append(contextExtractors.list, extractor)
+}
+
+// extractStandardKeys 提取 StandardContextKeys 中以字符串为键设置的值
+func extractStandardKeys(ctx context.Context) map[string]interface{} {
+	var fields map[string]interface{}
+	for _, key := range StandardContextKeys {
+		if value := ctx.Value(key); value != nil {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			fields[key] = value
+		}
 	}
+	return fields
+}
 
-	// 复制现有标签
-	for k, v := range c.Tags {
-		newCtx.Tags[k] = v
+// extractContextFields 依次执行默认提取和已注册的提取器，返回提取到的字段
+func extractContextFields(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
 	}
 
-	return newCtx
+	fields := extractStandardKeys(ctx)
+
+	contextExtractors.mu.RLock()
+	defer contextExtractors.mu.RUnlock()
+	for _, extractor := range contextExtractors.list {
+		for k, v := range extractor(ctx) {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			fields[k] = v
+		}
+	}
+	return fields
 }
 
 // 生成唯一ID
@@ -146,10 +224,16 @@ func StartSpan(ctx context.Context, name string) (context.Context, *LogContext)
 	if parentLogCtx != nil {
 		// 如果存在父上下文，继承追踪ID
 		logCtx = &LogContext{
-			TraceID:  parentLogCtx.TraceID,
-			SpanID:   generateID(),
-			ParentID: parentLogCtx.SpanID,
-			Tags:     make(map[string]string),
+			TraceID:       parentLogCtx.TraceID,
+			SpanID:        generateID(),
+			ParentID:      parentLogCtx.SpanID,
+			Tags:          make(map[string]string),
+			Fields:        make(map[string]interface{}, len(parentLogCtx.Fields)),
+			LevelOverride: parentLogCtx.LevelOverride,
+		}
+		// 继承上下文字段
+		for k, v := range parentLogCtx.Fields {
+			logCtx.Fields[k] = v
 		}
 		logCtx.Tags["span_name"] = name
 		logCtx.Tags["start_time"] = fmt.Sprintf("%d", time.Now().UnixNano())
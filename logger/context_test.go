@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestAddContextField(t *testing.T) {
+	var buf bytes.Buffer
+	handler := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), DebugLevel),
+		writer:      &buf,
+	}
+	base := NewStandardLogger("test", DebugLevel, handler)
+
+	ctx := AddContextField(context.Background(), "tenant_id", "acme")
+	log := base.WithContext(ctx)
+
+	log.Info("first")
+	log.WithField("step", 2).Info("second")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"tenant_id":"acme"`) {
+			t.Errorf("Expected tenant_id on every log line, got %s", line)
+		}
+	}
+}
+
+type otelSpanKey struct{}
+
+func TestWithContextExtractsFields(t *testing.T) {
+	var buf bytes.Buffer
+	handler := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), DebugLevel),
+		writer:      &buf,
+	}
+	base := NewStandardLogger("test", DebugLevel, handler)
+
+	RegisterContextExtractor(func(ctx context.Context) map[string]interface{} {
+		if span, ok := ctx.Value(otelSpanKey{}).(string); ok {
+			return map[string]interface{}{"otel_span": span}
+		}
+		return nil
+	})
+
+	// 标准键以字符串设置
+	ctx := context.WithValue(context.Background(), "trace_id", "t-1")
+	ctx = context.WithValue(ctx, "request_id", "r-1")
+	ctx = context.WithValue(ctx, otelSpanKey{}, "s-1")
+	ctx = AddContextField(ctx, "request_id", "explicit")
+
+	base.WithContext(ctx).Info("hello")
+
+	line := buf.String()
+	for _, want := range []string{`"trace_id":"t-1"`, `"otel_span":"s-1"`, `"request_id":"explicit"`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %s in %s", want, line)
+		}
+	}
+	if strings.Contains(line, `"context"`) {
+		t.Errorf("Expected no opaque context field, got %s", line)
+	}
+}
+
+func TestWithLevelOverride(t *testing.T) {
+	var buf bytes.Buffer
+	handler := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), InfoLevel),
+		writer:      &buf,
+	}
+	base := NewStandardLogger("test", InfoLevel, handler)
+
+	base.Debug("global debug")
+	ctx := WithLevelOverride(context.Background(), DebugLevel)
+	base.WithContext(ctx).Debug("request debug")
+	base.WithContext(context.Background()).Debug("other request debug")
+
+	output := buf.String()
+	if strings.Contains(output, "global debug") || strings.Contains(output, "other request debug") {
+		t.Errorf("Expected debug logs without override to be dropped, got %s", output)
+	}
+	if !strings.Contains(output, "request debug") {
+		t.Errorf("Expected debug log with override to be written, got %s", output)
+	}
+
+	if level, ok := LevelOverrideFromContext(ctx); !ok || level != DebugLevel {
+		t.Errorf("Expected DebugLevel override, got %v %v", level, ok)
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// globalFields 全局字段，会附加到每一条日志中
+var globalFields struct {
+	fields map[string]interface{}
+	mu     sync.RWMutex
+}
+
+// SetGlobalFields 设置全局字段，替换已有的全部全局字段
+// 常用于在启动时添加 {"app": "myservice", "env": "prod"} 等固定字段
+func SetGlobalFields(fields map[string]interface{}) {
+	newFields := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		newFields[k] = v
+	}
+
+	globalFields.mu.Lock()
+	globalFields.fields = newFields
+	globalFields.mu.Unlock()
+}
+
+// AddGlobalField 添加单个全局字段
+func AddGlobalField(key string, value interface{}) {
+	globalFields.mu.Lock()
+	defer globalFields.mu.Unlock()
+
+	if globalFields.fields == nil {
+		globalFields.fields = make(map[string]interface{})
+	}
+	globalFields.fields[key] = value
+}
+
+// ClearGlobalFields 清空全局字段
+func ClearGlobalFields() {
+	globalFields.mu.Lock()
+	globalFields.fields = nil
+	globalFields.mu.Unlock()
+}
+
+// processGoroutineID 是否为每条日志添加 goroutine 字段
+var processGoroutineID atomic.Bool
+
+// WithProcessFields 为所有日志添加进程信息字段：hostname、pid
+// withGoroutineID 为 true 时还会为每条日志添加 goroutine 字段，获取ID需要读取调用栈，有一定开销
+// hostname 和 pid 只在调用时计算一次，以全局字段的形式附加
+func WithProcessFields(withGoroutineID bool) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	AddGlobalField("hostname", hostname)
+	AddGlobalField("pid", os.Getpid())
+	processGoroutineID.Store(withGoroutineID)
+}
+
+// goroutineID 从调用栈首行 "goroutine 123 [running]:" 中解析当前 goroutine 的ID
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+// copyGlobalFields 将全局字段复制到目标map中
+func copyGlobalFields(dst map[string]interface{}) {
+	globalFields.mu.RLock()
+	defer globalFields.mu.RUnlock()
+
+	for k, v := range globalFields.fields {
+		dst[k] = v
+	}
+	if processGoroutineID.Load() {
+		dst["goroutine"] = goroutineID()
+	}
+}
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestWithProcessFields(t *testing.T) {
+	defer func() {
+		ClearGlobalFields()
+		processGoroutineID.Store(false)
+	}()
+
+	handler := NewMemoryHandler(NewJSONFormatter(), DebugLevel, DefaultMemoryConfig)
+	defer handler.Close()
+	log := NewStandardLogger("test", DebugLevel, handler)
+
+	WithProcessFields(true)
+	log.Info("hello")
+
+	entries := NewMemoryHandlerAPI(handler).GetLatest(1)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].Event.Fields
+
+	hostname, _ := os.Hostname()
+	if hostname != "" && fields["hostname"] != hostname {
+		t.Errorf("Expected hostname %q, got %v", hostname, fields["hostname"])
+	}
+	if fields["pid"] != os.Getpid() {
+		t.Errorf("Expected pid %d, got %v", os.Getpid(), fields["pid"])
+	}
+	if id, ok := fields["goroutine"].(uint64); !ok || id == 0 {
+		t.Errorf("Expected goroutine ID, got %v", fields["goroutine"])
+	}
+}
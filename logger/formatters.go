@@ -1,19 +1,69 @@
 package logger
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 )
 
 // JSONFormatter JSON格式化器
-type JSONFormatter struct{}
+type JSONFormatter struct {
+	// FlattenNested 是否将嵌套的map/结构体字段展开为点分隔的键，如 user.id
+	FlattenNested bool
+	// Separator 展开键的分隔符，默认为 "."
+	Separator string
+	// DisableNewline 是否去掉每条日志末尾的换行符
+	DisableNewline bool
+	// DisableHTMLEscape 是否关闭对 <、>、& 的HTML转义
+	DisableHTMLEscape bool
+	// TimestampKey 时间戳字段名，默认为 "timestamp"
+	TimestampKey string
+	// TimestampFormat 时间戳格式，默认为 time.RFC3339Nano
+	TimestampFormat string
+}
+
+// JSONOption JSON格式化器选项
+type JSONOption func(*JSONFormatter)
+
+// WithTrailingNewline 设置是否在每条日志末尾添加换行符，默认添加
+func WithTrailingNewline(enable bool) JSONOption {
+	return func(f *JSONFormatter) {
+		f.DisableNewline = !enable
+	}
+}
+
+// WithHTMLEscape 设置是否对 <、>、& 进行HTML转义，默认转义
+func WithHTMLEscape(enable bool) JSONOption {
+	return func(f *JSONFormatter) {
+		f.DisableHTMLEscape = !enable
+	}
+}
+
+// WithTimestampKey 设置时间戳字段名
+func WithTimestampKey(key string) JSONOption {
+	return func(f *JSONFormatter) {
+		f.TimestampKey = key
+	}
+}
+
+// WithTimestampFormat 设置时间戳格式，语法与 time.Format 相同
+func WithTimestampFormat(layout string) JSONOption {
+	return func(f *JSONFormatter) {
+		f.TimestampFormat = layout
+	}
+}
 
 // NewJSONFormatter 创建JSON格式化器
-func NewJSONFormatter() *JSONFormatter {
-	return &JSONFormatter{}
+func NewJSONFormatter(opts ...JSONOption) *JSONFormatter {
+	f := &JSONFormatter{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 // Format 格式化日志事件为JSON
@@ -21,7 +71,15 @@ func (f *JSONFormatter) Format(event LogEvent) ([]byte, error) {
 	data := make(map[string]interface{})
 
 	// 添加基本字段
-	data["timestamp"] = time.Unix(0, event.Time).Format(time.RFC3339Nano)
+	timestampKey := f.TimestampKey
+	if timestampKey == "" {
+		timestampKey = "timestamp"
+	}
+	timestampFormat := f.TimestampFormat
+	if timestampFormat == "" {
+		timestampFormat = time.RFC3339Nano
+	}
+	data[timestampKey] = time.Unix(0, event.Time).Format(timestampFormat)
 	data["level"] = levelToString(event.Level)
 	data["message"] = event.Message
 	data["pid"] = os.Getpid() // 添加进程ID
@@ -53,21 +111,94 @@ func (f *JSONFormatter) Format(event LogEvent) ([]byte, error) {
 	}
 
 	// 添加自定义字段
-	for k, v := range event.Fields {
+	fields := event.Fields
+	if f.FlattenNested {
+		fields = f.flattenFields(fields)
+	}
+	for k, v := range fields {
 		// 避免覆盖基本字段
 		if _, exists := data[k]; !exists {
 			data[k] = v
 		}
 	}
 
-	// 转换为JSON
-	jsonData, err := json.Marshal(data)
-	if err != nil {
+	// 转换为JSON，Encoder 会在末尾添加换行符
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(!f.DisableHTMLEscape)
+	if err := encoder.Encode(data); err != nil {
 		return nil, err
 	}
 
-	// 添加换行符
-	return append(jsonData, '\n'), nil
+	if f.DisableNewline {
+		return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+	}
+	return buf.Bytes(), nil
+}
+
+// flattenFields 将嵌套字段展开为点分隔的键
+func (f *JSONFormatter) flattenFields(fields map[string]interface{}) map[string]interface{} {
+	sep := f.Separator
+	if sep == "" {
+		sep = "."
+	}
+
+	result := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		flattenValue(result, k, v, sep)
+	}
+	return result
+}
+
+// flattenValue 递归展开单个字段值
+func flattenValue(result map[string]interface{}, prefix string, value interface{}, sep string) {
+	nested, ok := toNestedMap(value)
+	if !ok || len(nested) == 0 {
+		result[prefix] = value
+		return
+	}
+	for k, v := range nested {
+		flattenValue(result, prefix+sep+k, v, sep)
+	}
+}
+
+// toNestedMap 将map或结构体转换为 map[string]interface{}
+// 结构体通过JSON编解码转换，以遵循其json标签
+func toNestedMap(value interface{}) (map[string]interface{}, bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, true
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, false
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+	case reflect.Struct:
+		if _, ok := rv.Interface().(time.Time); ok {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, false
+	}
+	return m, true
 }
 
 // TextFormatter 文本格式化器
@@ -128,6 +259,8 @@ func (f *TextFormatter) Format(event LogEvent) ([]byte, error) {
 // levelToString 将日志级别转换为字符串
 func levelToString(level LogLevel) string {
 	switch level {
+	case TraceLevel:
+		return "TRACE"
 	case DebugLevel:
 		return "DEBUG"
 	case InfoLevel:
@@ -146,6 +279,8 @@ func levelToString(level LogLevel) string {
 // ParseLevel 解析日志级别字符串
 func ParseLevel(level string) (LogLevel, error) {
 	switch strings.ToLower(level) {
+	case "trace":
+		return TraceLevel, nil
 	case "debug":
 		return DebugLevel, nil
 	case "info":
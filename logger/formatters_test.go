@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatterFlattenNested(t *testing.T) {
+	formatter := NewJSONFormatter()
+	formatter.FlattenNested = true
+
+	event := LogEvent{
+		Time:    time.Now().UnixNano(),
+		Level:   InfoLevel,
+		Message: "login",
+		Fields: map[string]interface{}{
+			"user": map[string]interface{}{
+				"id":   42,
+				"name": "alice",
+			},
+		},
+	}
+
+	data, err := formatter.Format(event)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	output := string(data)
+	if !strings.Contains(output, `"user.id":42`) {
+		t.Errorf("Expected flattened user.id, got %s", output)
+	}
+	if !strings.Contains(output, `"user.name":"alice"`) {
+		t.Errorf("Expected flattened user.name, got %s", output)
+	}
+	if strings.Contains(output, `"user":{`) {
+		t.Errorf("Expected no nested user object, got %s", output)
+	}
+}
+
+func TestJSONFormatterOptions(t *testing.T) {
+	event := LogEvent{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixNano(),
+		Level:   InfoLevel,
+		Message: "<b>a & b</b>",
+	}
+
+	data, err := NewJSONFormatter().Format(event)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if strings.Contains(string(data), "<b>") {
+		t.Errorf("Expected HTML to be escaped by default, got %s", data)
+	}
+	if !strings.HasSuffix(string(data), "\n") {
+		t.Errorf("Expected trailing newline by default, got %q", data)
+	}
+
+	formatter := NewJSONFormatter(
+		WithHTMLEscape(false),
+		WithTrailingNewline(false),
+		WithTimestampKey("@timestamp"),
+		WithTimestampFormat("2006-01-02"),
+	)
+	data, err = formatter.Format(event)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	output := string(data)
+	if !strings.Contains(output, `"message":"<b>a & b</b>"`) {
+		t.Errorf("Expected unescaped HTML, got %s", output)
+	}
+	if strings.HasSuffix(output, "\n") {
+		t.Errorf("Expected no trailing newline, got %q", output)
+	}
+	if !strings.Contains(output, `"@timestamp":"`) || strings.Contains(output, `"timestamp"`) {
+		t.Errorf("Expected custom timestamp key, got %s", output)
+	}
+	if !strings.Contains(output, `"2024-01-`) {
+		t.Errorf("Expected custom timestamp format, got %s", output)
+	}
+}
+
+func TestTraceLevel(t *testing.T) {
+	level, err := ParseLevel("TRACE")
+	if err != nil || level != TraceLevel {
+		t.Fatalf("Expected TraceLevel, got %v (%v)", level, err)
+	}
+	if levelToString(TraceLevel) != "TRACE" {
+		t.Errorf("Expected TRACE, got %s", levelToString(TraceLevel))
+	}
+
+	var buf bytes.Buffer
+	handler := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewTextFormatter(), TraceLevel),
+		writer:      &buf,
+	}
+	log := NewStandardLogger("test", DebugLevel, handler)
+
+	log.Trace("hidden")
+	log.LazyTrace(func() string {
+		t.Error("Expected lazy function not to be called when Trace is disabled")
+		return ""
+	})
+	log.SetLevel(TraceLevel)
+	log.WithField("step", 1).Trace("visible")
+
+	output := buf.String()
+	if strings.Contains(output, "hidden") {
+		t.Errorf("Expected trace entry to be dropped at debug level, got %s", output)
+	}
+	if !strings.Contains(output, "TRACE") || !strings.Contains(output, "visible") {
+		t.Errorf("Expected trace entry at trace level, got %s", output)
+	}
+}
@@ -30,7 +30,11 @@ func (h *BaseHandler) Format(event LogEvent) ([]byte, error) {
 }
 
 // ShouldHandle 是否应该处理该事件
+// 事件上下文中设置了级别覆盖时，以覆盖级别为准
 func (h *BaseHandler) ShouldHandle(event LogEvent) bool {
+	if event.Context != nil && event.Context.LevelOverride != nil {
+		return event.Level >= *event.Context.LevelOverride
+	}
 	return event.Level >= h.level
 }
 
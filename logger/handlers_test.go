@@ -0,0 +1,260 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCallerSilenceHandler(t *testing.T) {
+	var buf bytes.Buffer
+	inner := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewTextFormatter(), DebugLevel),
+		writer:      &buf,
+	}
+	handler := NewCallerSilenceHandler(inner, []string{"vendor/go.etcd.io", "github.com/hashicorp/raft", "retry.go:"})
+
+	event := func(msg, file string, line int) LogEvent {
+		e := LogEvent{Time: time.Now().UnixNano(), Level: InfoLevel, Message: msg}
+		if file != "" {
+			e.Caller = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+			e.CallerFile = file
+		}
+		return e
+	}
+	silenced := []LogEvent{
+		event("noisy vendor", "/srv/app/vendor/go.etcd.io/etcd/client/v3/client.go", 42),
+		event("noisy module", "/root/go/pkg/mod/github.com/hashicorp/raft@v1.7.0/raft.go", 7),
+		event("noisy file", "/srv/app/internal/retry.go", 3),
+	}
+	passed := []LogEvent{
+		event("useful server", "/srv/app/internal/server.go", 10),
+		// 前缀需要从路径段开始匹配
+		event("useful fork", "/srv/app/vendor/go.etcd.io.fork/client.go", 5),
+		event("useful unknown", "", 0),
+	}
+
+	for _, e := range silenced {
+		if handler.ShouldHandle(e) {
+			t.Errorf("Expected caller %s to be rejected", e.CallerFile)
+		}
+		if err := handler.Handle(e); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+	for _, e := range passed {
+		if err := handler.Handle(e); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "noisy") {
+		t.Errorf("Expected silenced entries to be dropped, got %s", output)
+	}
+	for _, e := range passed {
+		if !strings.Contains(output, e.Message) {
+			t.Errorf("Expected %q to pass through, got %s", e.Message, output)
+		}
+	}
+}
+
+func TestCallerSilenceHandlerWithLogger(t *testing.T) {
+	inner := NewMemoryHandler(NewJSONFormatter(), DebugLevel, DefaultMemoryConfig)
+	defer inner.Close()
+	api := NewMemoryHandlerAPI(inner)
+
+	// 记录的调用者是业务代码所在的文件，而不是日志包内部的文件
+	log := NewStandardLogger("test", DebugLevel, NewCallerSilenceHandler(inner, []string{"logger/formatters_test.go"}))
+	log.WithField("k", "v").Info("from handlers test")
+	entries := api.GetLatest(1)
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	if caller := entries[0].Event.Caller; !strings.HasPrefix(caller, "handlers_test.go:") {
+		t.Errorf("Expected caller in handlers_test.go, got %s", caller)
+	}
+	if file := entries[0].Event.CallerFile; !strings.HasSuffix(file, "/logger/handlers_test.go") {
+		t.Errorf("Expected full caller path, got %s", file)
+	}
+
+	silenced := NewStandardLogger("test", DebugLevel, NewCallerSilenceHandler(inner, []string{"logger/handlers_test.go"}))
+	silenced.Info("silenced by path")
+	if got := len(api.GetContaining("silenced by path", 0)); got != 0 {
+		t.Errorf("Expected entry from silenced path to be dropped, got %d", got)
+	}
+}
+
+func TestSamplingHandler(t *testing.T) {
+	inner := NewMemoryHandler(NewJSONFormatter(), DebugLevel, DefaultMemoryConfig)
+	defer inner.Close()
+	handler := NewSamplingHandler(inner, SamplingConfig{Max: 3, Interval: 50 * time.Millisecond})
+
+	for i := 0; i < 10; i++ {
+		if err := handler.Handle(LogEvent{Time: time.Now().UnixNano(), Level: InfoLevel, Message: "flood", Fields: map[string]interface{}{}}); err != nil {
+			t.Fatalf("Handle failed: %v", err)
+		}
+	}
+	if err := handler.Handle(LogEvent{Time: time.Now().UnixNano(), Level: InfoLevel, Message: "other"}); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+
+	api := NewMemoryHandlerAPI(inner)
+	if got := len(api.GetContaining("flood", 100)); got != 3 {
+		t.Errorf("Expected 3 sampled entries, got %d", got)
+	}
+	if got := len(api.GetContaining("other", 100)); got != 1 {
+		t.Errorf("Expected distinct message to pass through, got %d", got)
+	}
+
+	// 下一个周期的第一条日志带上被丢弃的条数
+	time.Sleep(60 * time.Millisecond)
+	if err := handler.Handle(LogEvent{Time: time.Now().UnixNano(), Level: InfoLevel, Message: "flood"}); err != nil {
+		t.Fatalf("Handle failed: %v", err)
+	}
+	latest := api.GetLatest(1)
+	if len(latest) != 1 || latest[0].Event.Fields["sampled_dropped"] != int64(7) {
+		t.Errorf("Expected sampled_dropped=7, got %v", latest)
+	}
+}
+
+func TestAlertHandler(t *testing.T) {
+	received := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	handler, err := NewAlertHandler(NewJSONFormatter(), FatalLevel, server.URL, time.Second)
+	if err != nil {
+		t.Fatalf("NewAlertHandler failed: %v", err)
+	}
+	log := NewStandardLogger("test", DebugLevel, handler)
+
+	// 直接调用 log 避免 Fatal 退出进程
+	log.log(ErrorLevel, "below threshold")
+	log.log(FatalLevel, "database unreachable")
+
+	// 告警是同步发送的，无需等待
+	select {
+	case body := <-received:
+		if !strings.Contains(body, "database unreachable") {
+			t.Errorf("Expected fatal entry in webhook body, got %s", body)
+		}
+	default:
+		t.Fatal("Expected webhook to receive the fatal entry")
+	}
+	select {
+	case body := <-received:
+		t.Errorf("Expected only one alert, got extra %s", body)
+	default:
+	}
+}
+
+// slowHandler 每条日志都需要一段时间处理的处理器
+type slowHandler struct {
+	*BaseHandler
+	delay   time.Duration
+	handled int
+}
+
+func (h *slowHandler) Handle(event LogEvent) error {
+	time.Sleep(h.delay)
+	h.handled++
+	return nil
+}
+
+func TestCloseWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	fast := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), DebugLevel),
+		writer:      &buf,
+	}
+	slow := NewAsyncHandler(&slowHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), DebugLevel),
+		delay:       20 * time.Millisecond,
+	}, 100)
+	log := NewStandardLogger("test", DebugLevel, fast, slow)
+
+	for i := 0; i < 10; i++ {
+		log.Info("queued")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	err := log.CloseWithContext(ctx)
+
+	var closeErr *CloseError
+	if !errors.As(err, &closeErr) {
+		t.Fatalf("Expected *CloseError, got %v", err)
+	}
+	if len(closeErr.Handlers) != 1 || closeErr.Handlers[0] != Handler(slow) {
+		t.Errorf("Expected only the async handler to be reported, got %v", closeErr.Handlers)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+
+	// 再次关闭不会重复报告
+	if err := slow.CloseWithContext(context.Background()); err != nil {
+		t.Errorf("Expected second close to return nil, got %v", err)
+	}
+}
+
+// gateHandler 第一条日志阻塞到 release 关闭为止的处理器
+type gateHandler struct {
+	*BaseHandler
+	started chan struct{}
+	release chan struct{}
+	once    bool
+}
+
+func (h *gateHandler) Handle(event LogEvent) error {
+	if !h.once {
+		h.once = true
+		close(h.started)
+		<-h.release
+	}
+	return nil
+}
+
+func TestAsyncHandlerMetrics(t *testing.T) {
+	gate := &gateHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), DebugLevel),
+		started:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+	async := NewAsyncHandler(gate, 2)
+	log := NewStandardLogger("test", DebugLevel, async)
+
+	log.Info("first")
+	<-gate.started
+	for i := 0; i < 5; i++ {
+		log.Info("queued")
+	}
+
+	metrics := log.GetHandlerMetrics()
+	if metrics.QueueDepth != 2 || metrics.TotalDropped != 3 || metrics.TotalProcessed != 0 {
+		t.Errorf("Unexpected metrics while blocked: %+v", metrics)
+	}
+
+	close(gate.release)
+	if err := async.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	metrics = async.Metrics()
+	want := AsyncHandlerMetrics{QueueDepth: 0, TotalDropped: 3, TotalProcessed: 3, BatchCount: 1}
+	if metrics != want {
+		t.Errorf("Expected %+v, got %+v", want, metrics)
+	}
+}
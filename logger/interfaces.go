@@ -16,27 +16,35 @@ const (
 	FatalLevel
 )
 
+// TraceLevel 比Debug更详细的诊断日志级别，取值低于 DebugLevel 以保持已有级别的取值不变
+const TraceLevel LogLevel = DebugLevel - 1
+
 // LogEvent 日志事件
 type LogEvent struct {
-	Time    int64                  // 时间戳
-	Level   LogLevel               // 日志级别
-	Message string                 // 日志消息
-	Fields  map[string]interface{} // 额外字段
-	Caller  string                 // 调用者信息
-	Context *LogContext            // 上下文信息
-	Logger  string                 // 日志记录器名称
+	Time       int64                  // 时间戳
+	Level      LogLevel               // 日志级别
+	Message    string                 // 日志消息
+	Fields     map[string]interface{} // 额外字段
+	Caller     string                 // 调用者信息（file.go:line）
+	CallerFile string                 // 调用者源文件的完整路径，用于按目录或模块路径屏蔽日志
+	Context    *LogContext            // 上下文信息
+	Logger     string                 // 日志记录器名称
 }
 
 // LogContext 日志上下文
 type LogContext struct {
-	TraceID  string            // 追踪ID
-	SpanID   string            // 跨度ID
-	ParentID string            // 父跨度ID
-	Tags     map[string]string // 上下文标签
+	TraceID  string                 // 追踪ID
+	SpanID   string                 // 跨度ID
+	ParentID string                 // 父跨度ID
+	Tags     map[string]string      // 上下文标签
+	Fields   map[string]interface{} // 上下文字段，会附加到该上下文派生的所有日志中
+	// LevelOverride 请求级别的日志级别覆盖，设置后优先于记录器和处理器的级别
+	LevelOverride *LogLevel
 }
 
 // LoggerInterface 日志记录器接口
 type LoggerInterface interface {
+	Trace(msg string)
 	Debug(msg string)
 	Info(msg string)
 	Warn(msg string)
@@ -0,0 +1,183 @@
+package logger
+
+import "fmt"
+
+// LazyTrace 输出Trace级别日志，仅在Trace级别启用时才调用 fn 生成消息
+func (l *StandardLogger) LazyTrace(fn func() string) {
+	if l.enabled(TraceLevel) {
+		l.log(TraceLevel, fn())
+	}
+}
+
+// LazyDebug 输出Debug级别日志，仅在Debug级别启用时才调用 fn 生成消息
+func (l *StandardLogger) LazyDebug(fn func() string) {
+	if l.enabled(DebugLevel) {
+		l.log(DebugLevel, fn())
+	}
+}
+
+// LazyInfo 输出Info级别日志，仅在Info级别启用时才调用 fn 生成消息
+func (l *StandardLogger) LazyInfo(fn func() string) {
+	if l.enabled(InfoLevel) {
+		l.log(InfoLevel, fn())
+	}
+}
+
+// LazyWarn 输出Warn级别日志，仅在Warn级别启用时才调用 fn 生成消息
+func (l *StandardLogger) LazyWarn(fn func() string) {
+	if l.enabled(WarnLevel) {
+		l.log(WarnLevel, fn())
+	}
+}
+
+// LazyError 输出Error级别日志，仅在Error级别启用时才调用 fn 生成消息
+func (l *StandardLogger) LazyError(fn func() string) {
+	if l.enabled(ErrorLevel) {
+		l.log(ErrorLevel, fn())
+	}
+}
+
+// LazyTracef 输出Trace级别日志，仅在Trace级别启用时才调用 fn 获取格式和参数
+func (l *StandardLogger) LazyTracef(fn func() (string, []interface{})) {
+	if l.enabled(TraceLevel) {
+		l.log(TraceLevel, lazySprintf(fn))
+	}
+}
+
+// LazyDebugf 输出Debug级别日志，仅在Debug级别启用时才调用 fn 获取格式和参数
+func (l *StandardLogger) LazyDebugf(fn func() (string, []interface{})) {
+	if l.enabled(DebugLevel) {
+		l.log(DebugLevel, lazySprintf(fn))
+	}
+}
+
+// LazyInfof 输出Info级别日志，仅在Info级别启用时才调用 fn 获取格式和参数
+func (l *StandardLogger) LazyInfof(fn func() (string, []interface{})) {
+	if l.enabled(InfoLevel) {
+		l.log(InfoLevel, lazySprintf(fn))
+	}
+}
+
+// LazyWarnf 输出Warn级别日志，仅在Warn级别启用时才调用 fn 获取格式和参数
+func (l *StandardLogger) LazyWarnf(fn func() (string, []interface{})) {
+	if l.enabled(WarnLevel) {
+		l.log(WarnLevel, lazySprintf(fn))
+	}
+}
+
+// LazyErrorf 输出Error级别日志，仅在Error级别启用时才调用 fn 获取格式和参数
+func (l *StandardLogger) LazyErrorf(fn func() (string, []interface{})) {
+	if l.enabled(ErrorLevel) {
+		l.log(ErrorLevel, lazySprintf(fn))
+	}
+}
+
+// lazySprintf 调用 fn 并格式化消息
+func lazySprintf(fn func() (string, []interface{})) string {
+	format, args := fn()
+	return fmt.Sprintf(format, args...)
+}
+
+// lazyLogger 支持延迟求值的日志记录器
+type lazyLogger interface {
+	LazyTrace(fn func() string)
+	LazyDebug(fn func() string)
+	LazyInfo(fn func() string)
+	LazyWarn(fn func() string)
+	LazyError(fn func() string)
+	LazyTracef(fn func() (string, []interface{}))
+	LazyDebugf(fn func() (string, []interface{}))
+	LazyInfof(fn func() (string, []interface{}))
+	LazyWarnf(fn func() (string, []interface{}))
+	LazyErrorf(fn func() (string, []interface{}))
+}
+
+// LazyTrace 输出Trace级别日志，仅在Trace级别启用时才调用 fn 生成消息
+func LazyTrace(fn func() string) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyTrace(fn)
+		return
+	}
+	GetDefaultLogger().Trace(fn())
+}
+
+// LazyDebug 输出Debug级别日志，仅在Debug级别启用时才调用 fn 生成消息
+func LazyDebug(fn func() string) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyDebug(fn)
+		return
+	}
+	GetDefaultLogger().Debug(fn())
+}
+
+// LazyInfo 输出Info级别日志，仅在Info级别启用时才调用 fn 生成消息
+func LazyInfo(fn func() string) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyInfo(fn)
+		return
+	}
+	GetDefaultLogger().Info(fn())
+}
+
+// LazyWarn 输出Warn级别日志，仅在Warn级别启用时才调用 fn 生成消息
+func LazyWarn(fn func() string) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyWarn(fn)
+		return
+	}
+	GetDefaultLogger().Warn(fn())
+}
+
+// LazyError 输出Error级别日志，仅在Error级别启用时才调用 fn 生成消息
+func LazyError(fn func() string) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyError(fn)
+		return
+	}
+	GetDefaultLogger().Error(fn())
+}
+
+// LazyTracef 输出Trace级别日志，仅在Trace级别启用时才调用 fn 获取格式和参数
+func LazyTracef(fn func() (string, []interface{})) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyTracef(fn)
+		return
+	}
+	GetDefaultLogger().Trace(lazySprintf(fn))
+}
+
+// LazyDebugf 输出Debug级别日志，仅在Debug级别启用时才调用 fn 获取格式和参数
+func LazyDebugf(fn func() (string, []interface{})) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyDebugf(fn)
+		return
+	}
+	GetDefaultLogger().Debug(lazySprintf(fn))
+}
+
+// LazyInfof 输出Info级别日志，仅在Info级别启用时才调用 fn 获取格式和参数
+func LazyInfof(fn func() (string, []interface{})) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyInfof(fn)
+		return
+	}
+	GetDefaultLogger().Info(lazySprintf(fn))
+}
+
+// LazyWarnf 输出Warn级别日志，仅在Warn级别启用时才调用 fn 获取格式和参数
+func LazyWarnf(fn func() (string, []interface{})) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyWarnf(fn)
+		return
+	}
+	GetDefaultLogger().Warn(lazySprintf(fn))
+}
+
+// LazyErrorf 输出Error级别日志，仅在Error级别启用时才调用 fn 获取格式和参数
+func LazyErrorf(fn func() (string, []interface{})) {
+	if l, ok := GetDefaultLogger().(lazyLogger); ok {
+		l.LazyErrorf(fn)
+		return
+	}
+	GetDefaultLogger().Error(lazySprintf(fn))
+}
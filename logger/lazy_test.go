@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLazyLogging(t *testing.T) {
+	var buf bytes.Buffer
+	handler := &CustomHandler{
+		BaseHandler: NewBaseHandler(NewJSONFormatter(), DebugLevel),
+		writer:      &buf,
+	}
+	log := NewStandardLogger("test", InfoLevel, handler)
+
+	called := false
+	log.LazyDebug(func() string {
+		called = true
+		return "expensive"
+	})
+	log.LazyDebugf(func() (string, []interface{}) {
+		called = true
+		return "expensive %v", []interface{}{struct{}{}}
+	})
+	if called {
+		t.Error("Expected lazy function not to be called when Debug is disabled")
+	}
+
+	log.LazyInfof(func() (string, []interface{}) {
+		return "user %s logged in", []interface{}{"alice"}
+	})
+	if !strings.Contains(buf.String(), "user alice logged in") {
+		t.Errorf("Expected formatted message, got %s", buf.String())
+	}
+}
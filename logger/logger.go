@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 )
@@ -24,6 +25,8 @@ type StandardLogger struct {
 	context    *LogContext
 	mu         sync.RWMutex
 	callerSkip int
+	// root 派生出当前记录器的根记录器，派生的记录器与根记录器共享处理器链和日志级别
+	root *StandardLogger
 }
 
 // NewStandardLogger 创建标准日志记录器
@@ -38,39 +41,77 @@ func NewStandardLogger(name string, level LogLevel, handlers ...Handler) *Standa
 	}
 }
 
-// SetLevel 设置日志级别
+// SetLevel 设置日志级别，派生的记录器与根记录器共享日志级别
 func (l *StandardLogger) SetLevel(level LogLevel) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.level = level
+	o := l.owner()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.level = level
 }
 
 // GetLevel 获取日志级别
 func (l *StandardLogger) GetLevel() LogLevel {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-	return l.level
+	o := l.owner()
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.level
+}
+
+// owner 返回持有处理器链和日志级别的记录器
+func (l *StandardLogger) owner() *StandardLogger {
+	if l.root != nil {
+		return l.root
+	}
+	return l
+}
+
+// handlerChain 返回当前处理器链的快照
+func (l *StandardLogger) handlerChain() []Handler {
+	o := l.owner()
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.handlers
 }
 
 // AddHandler 添加处理器
 func (l *StandardLogger) AddHandler(handler Handler) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	l.handlers = append(l.handlers, handler)
+	o := l.owner()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.handlers = append(o.handlers[:len(o.handlers):len(o.handlers)], handler)
 }
 
 // RemoveHandler 移除处理器
 func (l *StandardLogger) RemoveHandler(handler Handler) {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-	for i, h := range l.handlers {
+	o := l.owner()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for i, h := range o.handlers {
 		if h == handler {
-			l.handlers = append(l.handlers[:i], l.handlers[i+1:]...)
+			handlers := make([]Handler, 0, len(o.handlers)-1)
+			handlers = append(handlers, o.handlers[:i]...)
+			o.handlers = append(handlers, o.handlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// replaceHandlers 原子地替换日志级别和处理器链，返回被替换的处理器
+func (l *StandardLogger) replaceHandlers(level LogLevel, handlers []Handler) []Handler {
+	o := l.owner()
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	old := o.handlers
+	o.level = level
+	o.handlers = handlers
+	return old
+}
+
+// Trace 输出Trace级别日志
+func (l *StandardLogger) Trace(msg string) {
+	l.log(TraceLevel, msg)
+}
+
 // Debug 输出Debug级别日志
 func (l *StandardLogger) Debug(msg string) {
 	l.log(DebugLevel, msg)
@@ -97,14 +138,22 @@ func (l *StandardLogger) Fatal(msg string) {
 	os.Exit(1)
 }
 
+// enabled 检查指定级别的日志是否会被记录，上下文中的级别覆盖优先，其次是模块级别
+func (l *StandardLogger) enabled(level LogLevel) bool {
+	if l.context != nil && l.context.LevelOverride != nil {
+		return level >= *l.context.LevelOverride
+	}
+	if moduleLevel, ok := callerModuleLevel(); ok {
+		return level >= moduleLevel
+	}
+	return level >= l.GetLevel()
+}
+
 // log 处理日志记录
 func (l *StandardLogger) log(level LogLevel, msg string) {
-	l.mu.RLock()
-	if level < l.level {
-		l.mu.RUnlock()
+	if !l.enabled(level) {
 		return
 	}
-	l.mu.RUnlock()
 
 	// 创建日志事件
 	event := LogEvent{
@@ -116,6 +165,16 @@ func (l *StandardLogger) log(level LogLevel, msg string) {
 		Logger:  l.name,
 	}
 
+	// 复制全局字段，记录器自身的字段优先
+	copyGlobalFields(event.Fields)
+
+	// 复制上下文字段
+	if event.Context != nil {
+		for k, v := range event.Context.Fields {
+			event.Fields[k] = v
+		}
+	}
+
 	// 复制字段
 	l.mu.RLock()
 	for k, v := range l.fields {
@@ -124,23 +183,32 @@ func (l *StandardLogger) log(level LogLevel, msg string) {
 	l.mu.RUnlock()
 
 	// 添加调用者信息
-	if caller := l.getCaller(); caller != "" {
-		event.Caller = caller
+	if frame, ok := l.callerFrame(); ok {
+		event.Caller = fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		event.CallerFile = frame.File
 	}
 
 	// 发送给所有处理器
-	for _, handler := range l.handlers {
+	for _, handler := range l.handlerChain() {
 		_ = handler.Handle(event)
 	}
 }
 
-// getCaller 获取调用者信息
-func (l *StandardLogger) getCaller() string {
-	_, file, line, ok := runtime.Caller(l.callerSkip)
-	if !ok {
-		return ""
+// callerFrame 获取调用者的栈帧，跳过日志包自身的函数（测试文件除外），
+// 经 WithField、LogContext 等辅助函数调用时同样定位到业务代码
+func (l *StandardLogger) callerFrame() (runtime.Frame, bool) {
+	var pcs [16]uintptr
+	n := runtime.Callers(l.callerSkip+1, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if packageOf(frame.Function) != loggerPackage || strings.HasSuffix(frame.File, "_test.go") {
+			return frame, frame.File != ""
+		}
+		if !more {
+			return runtime.Frame{}, false
+		}
 	}
-	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
 }
 
 // WithField 添加单个字段
@@ -152,11 +220,10 @@ func (l *StandardLogger) WithField(key string, value interface{}) LoggerInterfac
 func (l *StandardLogger) WithFields(fields map[string]interface{}) LoggerInterface {
 	newLogger := &StandardLogger{
 		name:       l.name,
-		level:      l.level,
-		handlers:   l.handlers,
 		fields:     make(map[string]interface{}),
 		context:    l.context,
 		callerSkip: l.callerSkip,
+		root:       l.owner(),
 	}
 
 	// 复制现有字段
@@ -182,13 +249,22 @@ func (l *StandardLogger) WithContext(ctx context.Context) LoggerInterface {
 		logCtx = &LogContext{}
 	}
 
+	// 提取 trace_id 等上下文值作为字段，日志上下文中已有的字段优先
+	if extracted := extractContextFields(ctx); len(extracted) > 0 {
+		logCtx = logCtx.clone()
+		for k, v := range extracted {
+			if _, exists := logCtx.Fields[k]; !exists {
+				logCtx.Fields[k] = v
+			}
+		}
+	}
+
 	newLogger := &StandardLogger{
 		name:       l.name,
-		level:      l.level,
-		handlers:   l.handlers,
 		fields:     make(map[string]interface{}),
 		context:    logCtx,
 		callerSkip: l.callerSkip,
+		root:       l.owner(),
 	}
 
 	// 复制现有字段
@@ -201,10 +277,25 @@ func (l *StandardLogger) WithContext(ctx context.Context) LoggerInterface {
 	return newLogger
 }
 
+// GetHandlerMetrics 汇总所有异步处理器的运行指标
+func (l *StandardLogger) GetHandlerMetrics() AsyncHandlerMetrics {
+	var metrics AsyncHandlerMetrics
+	for _, handler := range l.handlerChain() {
+		if h, ok := handler.(*AsyncHandler); ok {
+			m := h.Metrics()
+			metrics.QueueDepth += m.QueueDepth
+			metrics.TotalDropped += m.TotalDropped
+			metrics.TotalProcessed += m.TotalProcessed
+			metrics.BatchCount += m.BatchCount
+		}
+	}
+	return metrics
+}
+
 // Sync 同步所有处理器
 func (l *StandardLogger) Sync() error {
 	var lastErr error
-	for _, handler := range l.handlers {
+	for _, handler := range l.handlerChain() {
 		if h, ok := handler.(*AsyncHandler); ok {
 			if err := h.Sync(); err != nil {
 				lastErr = err
@@ -217,7 +308,7 @@ func (l *StandardLogger) Sync() error {
 // Close 关闭所有处理器
 func (l *StandardLogger) Close() error {
 	var lastErr error
-	for _, handler := range l.handlers {
+	for _, handler := range l.handlerChain() {
 		if err := handler.Close(); err != nil {
 			lastErr = err
 		}
@@ -225,6 +316,56 @@ func (l *StandardLogger) Close() error {
 	return lastErr
 }
 
+// contextCloser 支持按期限关闭的处理器
+type contextCloser interface {
+	CloseWithContext(ctx context.Context) error
+}
+
+// CloseError 关闭处理器时的错误，记录关闭失败或未能在期限内处理完成的处理器
+type CloseError struct {
+	Handlers []Handler
+	Errors   []error
+}
+
+// Error 返回错误信息
+func (e *CloseError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d 个处理器关闭失败: %s", len(e.Handlers), strings.Join(msgs, "; "))
+}
+
+// Unwrap 返回各处理器的错误
+func (e *CloseError) Unwrap() []error {
+	return e.Errors
+}
+
+// CloseWithContext 关闭所有处理器，异步处理器会在 ctx 结束前尽量处理完队列中的事件
+// 返回的 *CloseError 中记录了关闭失败或未能及时处理完成的处理器
+func (l *StandardLogger) CloseWithContext(ctx context.Context) error {
+	handlers := l.handlerChain()
+
+	closeErr := &CloseError{}
+	for _, handler := range handlers {
+		var err error
+		if h, ok := handler.(contextCloser); ok {
+			err = h.CloseWithContext(ctx)
+		} else {
+			err = handler.Close()
+		}
+		if err != nil {
+			closeErr.Handlers = append(closeErr.Handlers, handler)
+			closeErr.Errors = append(closeErr.Errors, err)
+		}
+	}
+
+	if len(closeErr.Handlers) > 0 {
+		return closeErr
+	}
+	return nil
+}
+
 // LogManager 日志管理器
 type LogManager struct {
 	loggers map[string]LoggerInterface
@@ -256,13 +397,18 @@ func GetLogManager() *LogManager {
 
 // CloseAll 关闭所有日志记录器
 func (m *LogManager) CloseAll() error {
+	return m.CloseAllWithContext(context.Background())
+}
+
+// CloseAllWithContext 在 ctx 结束前关闭所有日志记录器，返回最后一个错误
+func (m *LogManager) CloseAllWithContext(ctx context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	var lastErr error
 	for _, logger := range m.loggers {
 		if l, ok := logger.(*StandardLogger); ok {
-			if err := l.Close(); err != nil {
+			if err := l.CloseWithContext(ctx); err != nil {
 				lastErr = err
 			}
 		}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// loggerPackage 日志包自身的包路径，查找调用者时跳过
+const loggerPackage = "github.com/ntshibin/core/logger"
+
+// moduleLevels 按模块（包路径前缀）设置的日志级别
+var moduleLevels struct {
+	levels map[string]LogLevel
+	count  atomic.Int32
+	mu     sync.RWMutex
+}
+
+// SetModuleLevel 为包路径等于 prefix 或位于 prefix 之下的调用者设置日志级别，覆盖日志记录器的级别
+// 多个前缀匹配时使用最长的前缀，上下文中的级别覆盖优先于模块级别；
+// 模块级别只决定记录器是否产生日志，事件仍需通过各处理器自身的级别
+func SetModuleLevel(prefix string, level LogLevel) {
+	moduleLevels.mu.Lock()
+	defer moduleLevels.mu.Unlock()
+
+	if moduleLevels.levels == nil {
+		moduleLevels.levels = make(map[string]LogLevel)
+	}
+	moduleLevels.levels[prefix] = level
+	moduleLevels.count.Store(int32(len(moduleLevels.levels)))
+}
+
+// ClearModuleLevels 清空所有模块级别
+func ClearModuleLevels() {
+	moduleLevels.mu.Lock()
+	defer moduleLevels.mu.Unlock()
+
+	moduleLevels.levels = nil
+	moduleLevels.count.Store(0)
+}
+
+// retainModuleLevels 只保留 prefixes 中列出的模块级别，重新加载配置时用于移除配置文件中已删除的模块
+func retainModuleLevels(prefixes map[string]string) {
+	moduleLevels.mu.Lock()
+	defer moduleLevels.mu.Unlock()
+
+	for prefix := range moduleLevels.levels {
+		if _, ok := prefixes[prefix]; !ok {
+			delete(moduleLevels.levels, prefix)
+		}
+	}
+	moduleLevels.count.Store(int32(len(moduleLevels.levels)))
+}
+
+// callerModuleLevel 获取调用者所在模块的日志级别，未设置模块级别时不查找调用栈
+func callerModuleLevel() (LogLevel, bool) {
+	if moduleLevels.count.Load() == 0 {
+		return 0, false
+	}
+
+	pkg := callerPackage()
+	if pkg == "" {
+		return 0, false
+	}
+
+	moduleLevels.mu.RLock()
+	defer moduleLevels.mu.RUnlock()
+
+	var (
+		level   LogLevel
+		matched = -1
+	)
+	for prefix, l := range moduleLevels.levels {
+		if matchModule(pkg, prefix) && len(prefix) > matched {
+			level, matched = l, len(prefix)
+		}
+	}
+	return level, matched >= 0
+}
+
+// matchModule 判断包路径是否等于 prefix 或位于 prefix 之下，按路径段匹配，pkg/foo 不匹配 pkg/foobar
+func matchModule(pkg, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return pkg == prefix || strings.HasPrefix(pkg, prefix+"/")
+}
+
+// callerPackage 获取调用栈中第一个不属于日志包的函数所在的包路径
+func callerPackage() string {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if pkg := packageOf(frame.Function); pkg != "" && pkg != loggerPackage {
+			return pkg
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// packageOf 从完整函数名中解析包路径，如 github.com/a/b.(*T).M 解析为 github.com/a/b
+func packageOf(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/ntshibin/core/logger"
+)
+
+func TestModuleLevel(t *testing.T) {
+	defer logger.ClearModuleLevels()
+
+	handler := logger.NewMemoryHandler(logger.NewJSONFormatter(), logger.DebugLevel, logger.DefaultMemoryConfig)
+	defer handler.Close()
+	logs := logger.NewMemoryHandlerAPI(handler)
+	log := logger.NewStandardLogger("test", logger.InfoLevel, handler)
+
+	// 当前测试包设置为 Warn，Info 日志被过滤
+	logger.SetModuleLevel("github.com/ntshibin/core/logger_test", logger.WarnLevel)
+	log.Info("filtered")
+	log.Warn("kept")
+	if entries := logs.GetContaining("filtered", 0); len(entries) != 0 {
+		t.Error("Expected info log to be filtered by module level")
+	}
+	if entries := logs.GetContaining("kept", 0); len(entries) != 1 {
+		t.Error("Expected warn log to pass module level")
+	}
+
+	// 更长的前缀优先，可以调低到 Debug
+	logger.SetModuleLevel("github.com/ntshibin/core/logger_test", logger.DebugLevel)
+	logger.SetModuleLevel("github.com/ntshibin/core", logger.ErrorLevel)
+	log.Debug("debug enabled")
+	if entries := logs.GetContaining("debug enabled", 0); len(entries) != 1 {
+		t.Error("Expected debug log to pass the most specific module level")
+	}
+}
+
+func TestModuleLevelKeepsHandlerThreshold(t *testing.T) {
+	defer logger.ClearModuleLevels()
+
+	debugHandler := logger.NewMemoryHandler(logger.NewJSONFormatter(), logger.DebugLevel, logger.DefaultMemoryConfig)
+	defer debugHandler.Close()
+	warnHandler := logger.NewMemoryHandler(logger.NewJSONFormatter(), logger.WarnLevel, logger.DefaultMemoryConfig)
+	defer warnHandler.Close()
+	log := logger.NewStandardLogger("test", logger.InfoLevel, debugHandler, warnHandler)
+
+	// 模块级别放开 Debug 日志，但 Warn 级别的处理器仍然过滤它
+	logger.SetModuleLevel("github.com/ntshibin/core/logger_test", logger.DebugLevel)
+	log.Debug("module debug")
+	if entries := logger.NewMemoryHandlerAPI(debugHandler).GetContaining("module debug", 0); len(entries) != 1 {
+		t.Error("Expected debug handler to receive the debug log")
+	}
+	if entries := logger.NewMemoryHandlerAPI(warnHandler).GetContaining("module debug", 0); len(entries) != 0 {
+		t.Error("Expected warn handler to keep its own threshold")
+	}
+
+	// 前缀按路径段匹配，github.com/ntshibin/core/log 不匹配 logger_test
+	logger.ClearModuleLevels()
+	logger.SetModuleLevel("github.com/ntshibin/core/log", logger.DebugLevel)
+	log.Debug("partial prefix")
+	if entries := logger.NewMemoryHandlerAPI(debugHandler).GetContaining("partial prefix", 0); len(entries) != 0 {
+		t.Error("Expected module prefix to match whole path segments")
+	}
+}
@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+)
+
+// HandlerFactory 处理器工厂，根据日志配置创建处理器
+// 配置未启用该处理器时返回 nil, nil
+type HandlerFactory func(config LoggerConfig, level LogLevel) (Handler, error)
+
+// handlerRegistry 处理器注册表
+var handlerRegistry = struct {
+	mu        sync.RWMutex
+	factories map[string]HandlerFactory
+}{
+	factories: make(map[string]HandlerFactory),
+}
+
+// builtinHandlers 内置处理器，按顺序根据配置中的 Enable* 开关创建
+var builtinHandlers = []string{"console", "file", "rotate", "remote", "memory", "alert"}
+
+func init() {
+	RegisterHandler("console", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		if !config.EnableConsole {
+			return nil, nil
+		}
+		var formatter Formatter
+		if config.Encoding == "json" {
+			formatter = NewJSONFormatter()
+		} else {
+			formatter = NewTextFormatter()
+		}
+		return NewConsoleHandler(formatter, level), nil
+	})
+
+	RegisterHandler("file", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		if !config.EnableFile || config.EnableRotate {
+			return nil, nil
+		}
+		return NewFileHandler(NewJSONFormatter(), level, config.FilePath)
+	})
+
+	RegisterHandler("rotate", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		if !config.EnableRotate {
+			return nil, nil
+		}
+		return NewRotateFileHandler(NewJSONFormatter(), level, config.Rotate)
+	})
+
+	RegisterHandler("remote", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		if !config.EnableRemote {
+			return nil, nil
+		}
+		return NewRemoteHandler(NewJSONFormatter(), level, config.Remote)
+	})
+
+	RegisterHandler("memory", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		if !config.EnableMemory {
+			return nil, nil
+		}
+		return NewMemoryHandler(NewJSONFormatter(), level, config.Memory), nil
+	})
+
+	RegisterHandler("alert", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		if config.AlertWebhook == "" {
+			return nil, nil
+		}
+		alertLevel := FatalLevel
+		if config.AlertLevel != "" {
+			var err error
+			if alertLevel, err = ParseLevel(config.AlertLevel); err != nil {
+				return nil, err
+			}
+		}
+		return NewAlertHandler(NewJSONFormatter(), alertLevel, config.AlertWebhook, DefaultAlertTimeout)
+	})
+}
+
+// RegisterHandler 注册处理器工厂，已存在的同名工厂会被替换
+// 注册后可以在 LoggerConfig.Handlers 中按名称启用
+func RegisterHandler(name string, factory HandlerFactory) {
+	handlerRegistry.mu.Lock()
+	defer handlerRegistry.mu.Unlock()
+	handlerRegistry.factories[name] = factory
+}
+
+// buildHandlers 根据配置创建内置处理器和 Handlers 中列出的处理器
+func buildHandlers(config LoggerConfig, level LogLevel) ([]Handler, error) {
+	handlerRegistry.mu.RLock()
+	defer handlerRegistry.mu.RUnlock()
+
+	names := append(append([]string(nil), builtinHandlers...), config.Handlers...)
+	var handlers []Handler
+	for _, name := range names {
+		factory, ok := handlerRegistry.factories[name]
+		if !ok {
+			closeHandlers(handlers)
+			return nil, fmt.Errorf("未注册的日志处理器: %s", name)
+		}
+		handler, err := factory(config, level)
+		if err != nil {
+			closeHandlers(handlers)
+			return nil, err
+		}
+		if handler != nil {
+			handlers = append(handlers, handler)
+		}
+	}
+	return handlers, nil
+}
+
+// closeHandlers 关闭已创建的处理器
+func closeHandlers(handlers []Handler) {
+	for _, handler := range handlers {
+		_ = handler.Close()
+	}
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/ntshibin/core/conf"
+)
+
+// ReloadFromFile 重新加载日志配置文件
+// 默认日志记录器为 StandardLogger 时，在持有其写锁的情况下原子地替换日志级别和处理器链，
+// 已通过 WithField、WithContext 派生的记录器同样使用新的处理器链，被替换的处理器随后关闭；
+// 配置无效时保留当前配置并返回错误
+func ReloadFromFile(filename string, opts ...conf.LoadOption) error {
+	config := DefaultLoggerConfig
+	if err := conf.LoadConfig(filename, &config, opts...); err != nil {
+		return fmt.Errorf("加载日志配置失败: %w", err)
+	}
+
+	current, ok := GetDefaultLogger().(*StandardLogger)
+	if !ok {
+		return LoadConfig(config)
+	}
+
+	fresh, err := newLoggerFromConfig(config)
+	if err != nil {
+		return err
+	}
+
+	// newLoggerFromConfig 已设置配置中的模块级别，这里只需移除配置文件中已删除的模块级别
+	retainModuleLevels(config.ModuleLevels)
+
+	old := current.replaceHandlers(fresh.level, fresh.handlers)
+	for _, handler := range old {
+		_ = handler.Close()
+	}
+	return nil
+}
+
+// ReloadOnSignal 收到 SIGHUP 信号时重新加载日志配置文件，无需重启进程
+// 重新加载失败时记录错误并保留当前配置，返回的函数用于停止监听，并等待正在进行的重新加载完成
+func ReloadOnSignal(filename string, opts ...conf.LoadOption) (stop func()) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case <-signals:
+				if err := ReloadFromFile(filename, opts...); err != nil {
+					WithField("file", filename).Error(fmt.Sprintf("重新加载日志配置失败: %v", err))
+					continue
+				}
+				WithField("file", filename).Info("日志配置已重新加载")
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+			<-exited
+		})
+	}
+}
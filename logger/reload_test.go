@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestReloadFromFile(t *testing.T) {
+	manager := GetLogManager()
+	manager.mu.RLock()
+	original := manager.loggers["default"]
+	manager.mu.RUnlock()
+	defer func() {
+		manager.mu.Lock()
+		manager.loggers["default"] = original
+		manager.mu.Unlock()
+	}()
+
+	var created []*MemoryHandler
+	RegisterHandler("reload-memory", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		h := NewMemoryHandler(NewJSONFormatter(), level, DefaultMemoryConfig)
+		created = append(created, h)
+		return h, nil
+	})
+
+	file := filepath.Join(t.TempDir(), "logger.yaml")
+	writeConfig := func(level string) {
+		t.Helper()
+		content := "level: " + level + "\nenable_console: false\nhandlers: [reload-memory]\n"
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+
+	writeConfig("info")
+	if err := ConfigureFromFile(file); err != nil {
+		t.Fatalf("ConfigureFromFile failed: %v", err)
+	}
+	logger := GetDefaultLogger().(*StandardLogger)
+	defer logger.Close()
+	derived := logger.WithField("component", "reload")
+
+	writeConfig("warn")
+	if err := ReloadFromFile(file); err != nil {
+		t.Fatalf("ReloadFromFile failed: %v", err)
+	}
+	if GetDefaultLogger() != logger {
+		t.Fatal("Expected reload to keep the default logger instance")
+	}
+	if logger.GetLevel() != WarnLevel {
+		t.Errorf("Expected level warn after reload, got %v", logger.GetLevel())
+	}
+
+	// 派生的记录器使用新的日志级别和处理器链
+	if level := derived.(*StandardLogger).GetLevel(); level != WarnLevel {
+		t.Errorf("Expected derived logger level warn after reload, got %v", level)
+	}
+	derived.Warn("after reload")
+	first, second := NewMemoryHandlerAPI(created[0]), NewMemoryHandlerAPI(created[1])
+	if got := len(first.GetLatest(10)); got != 0 {
+		t.Errorf("Expected replaced handler to receive nothing, got %d entries", got)
+	}
+	if got := len(second.GetLatest(10)); got != 1 {
+		t.Errorf("Expected new handler to receive 1 entry, got %d", got)
+	}
+
+	// 无效配置不影响当前配置
+	writeConfig("verbose")
+	if err := ReloadFromFile(file); err == nil {
+		t.Error("Expected error for invalid level")
+	}
+	if logger.GetLevel() != WarnLevel || len(logger.handlerChain()) != 1 {
+		t.Error("Expected failed reload to keep the current configuration")
+	}
+
+	// 收到 SIGHUP 时重新加载
+	stop := ReloadOnSignal(file)
+	defer stop()
+	writeConfig("error")
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("SIGHUP not supported: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for logger.GetLevel() != ErrorLevel && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if logger.GetLevel() != ErrorLevel {
+		t.Errorf("Expected level error after SIGHUP, got %v", logger.GetLevel())
+	}
+}
+
+func TestReloadFromFileWhileLogging(t *testing.T) {
+	manager := GetLogManager()
+	manager.mu.RLock()
+	original := manager.loggers["default"]
+	manager.mu.RUnlock()
+	defer func() {
+		manager.mu.Lock()
+		manager.loggers["default"] = original
+		manager.mu.Unlock()
+	}()
+	defer ClearModuleLevels()
+
+	RegisterHandler("reload-async", func(config LoggerConfig, level LogLevel) (Handler, error) {
+		return NewAsyncHandler(NewMemoryHandler(NewJSONFormatter(), level, DefaultMemoryConfig), 16), nil
+	})
+
+	file := filepath.Join(t.TempDir(), "logger.yaml")
+	writeConfig := func(moduleLevels string) {
+		t.Helper()
+		content := "level: info\nenable_console: false\nhandlers: [reload-async]\nmodule_levels: {" + moduleLevels + "}\n"
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+
+	writeConfig("github.com/app/old: debug")
+	if err := ConfigureFromFile(file); err != nil {
+		t.Fatalf("ConfigureFromFile failed: %v", err)
+	}
+	logger := GetDefaultLogger().(*StandardLogger)
+	defer logger.Close()
+
+	// 重新加载时关闭的异步处理器不能因正在进行的日志调用而 panic
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					logger.Info("while reloading")
+				}
+			}
+		}()
+	}
+
+	writeConfig("github.com/app/new: warn")
+	for i := 0; i < 50; i++ {
+		if err := ReloadFromFile(file); err != nil {
+			t.Fatalf("ReloadFromFile failed: %v", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	// 配置文件中删除的模块级别被移除
+	moduleLevels.mu.RLock()
+	_, hasOld := moduleLevels.levels["github.com/app/old"]
+	level, hasNew := moduleLevels.levels["github.com/app/new"]
+	moduleLevels.mu.RUnlock()
+	if hasOld {
+		t.Error("Expected removed module level to be cleared after reload")
+	}
+	if !hasNew || level != WarnLevel {
+		t.Errorf("Expected module level warn after reload, got %v (%v)", level, hasNew)
+	}
+}
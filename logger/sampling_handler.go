@@ -0,0 +1,135 @@
+package logger
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SamplingConfig 日志采样配置
+type SamplingConfig struct {
+	// Max 每个采样周期内相同日志最多记录的条数
+	Max int `yaml:"max" json:"max"`
+	// Interval 采样周期
+	Interval time.Duration `yaml:"interval" json:"interval"`
+}
+
+// DefaultSamplingConfig 默认采样配置
+var DefaultSamplingConfig = SamplingConfig{
+	Max:      100,
+	Interval: time.Second,
+}
+
+// samplingCounter 单条日志在当前采样周期内的计数
+type samplingCounter struct {
+	mu      sync.Mutex
+	start   time.Time
+	count   int
+	dropped int64
+}
+
+// SamplingHandler 日志采样处理器
+// 相同级别和消息的日志在一个采样周期内超过 Max 条后被丢弃，
+// 下一个周期记录的第一条日志会带上 sampled_dropped 字段，表示上一周期丢弃的条数
+type SamplingHandler struct {
+	handler   Handler
+	config    SamplingConfig
+	counters  sync.Map
+	lastSweep atomic.Int64
+}
+
+// NewSamplingHandler 创建日志采样处理器
+func NewSamplingHandler(handler Handler, config SamplingConfig) *SamplingHandler {
+	if config.Max <= 0 {
+		config.Max = DefaultSamplingConfig.Max
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultSamplingConfig.Interval
+	}
+
+	h := &SamplingHandler{
+		handler: handler,
+		config:  config,
+	}
+	h.lastSweep.Store(time.Now().UnixNano())
+	return h
+}
+
+// Handle 处理日志事件
+func (h *SamplingHandler) Handle(event LogEvent) error {
+	now := time.Now()
+	h.sweep(now)
+
+	value, _ := h.counters.LoadOrStore(samplingKey(event), &samplingCounter{start: now})
+	counter := value.(*samplingCounter)
+
+	counter.mu.Lock()
+	var dropped int64
+	if now.Sub(counter.start) >= h.config.Interval {
+		dropped = counter.dropped
+		counter.start = now
+		counter.count = 0
+		counter.dropped = 0
+	}
+	counter.count++
+	if counter.count > h.config.Max {
+		counter.dropped++
+		counter.mu.Unlock()
+		return nil
+	}
+	counter.mu.Unlock()
+
+	if dropped > 0 {
+		// 字段由所有处理器共享，复制后再添加
+		fields := make(map[string]interface{}, len(event.Fields)+1)
+		for k, v := range event.Fields {
+			fields[k] = v
+		}
+		fields["sampled_dropped"] = dropped
+		event.Fields = fields
+	}
+	return h.handler.Handle(event)
+}
+
+// Format 格式化日志事件
+func (h *SamplingHandler) Format(event LogEvent) ([]byte, error) {
+	return h.handler.Format(event)
+}
+
+// ShouldHandle 是否应该处理该事件
+func (h *SamplingHandler) ShouldHandle(event LogEvent) bool {
+	return h.handler.ShouldHandle(event)
+}
+
+// Close 关闭处理器
+func (h *SamplingHandler) Close() error {
+	return h.handler.Close()
+}
+
+// sweep 定期移除已结束且没有丢弃记录的计数，避免不同消息过多时内存持续增长
+func (h *SamplingHandler) sweep(now time.Time) {
+	last := h.lastSweep.Load()
+	if now.UnixNano()-last < int64(10*h.config.Interval) || !h.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	h.counters.Range(func(key, value interface{}) bool {
+		counter := value.(*samplingCounter)
+		counter.mu.Lock()
+		stale := now.Sub(counter.start) >= h.config.Interval && counter.dropped == 0
+		counter.mu.Unlock()
+		if stale {
+			h.counters.Delete(key)
+		}
+		return true
+	})
+}
+
+// samplingKey 根据日志级别和消息计算采样键
+func samplingKey(event LogEvent) uint64 {
+	hash := fnv.New64a()
+	hash.Write([]byte{byte(event.Level)})
+	hash.Write([]byte(event.Message))
+	return hash.Sum64()
+}
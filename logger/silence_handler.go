@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// CallerSilenceHandler 按调用者屏蔽日志的处理器
+// Silenced 中的前缀按路径段匹配调用者源文件的完整路径，如 vendor/go.etcd.io 或 github.com/foo/bar，
+// 也可以匹配调用者信息（file.go:line）的开头；匹配的事件会被丢弃，不受全局级别影响
+type CallerSilenceHandler struct {
+	handler  Handler
+	Silenced []string
+}
+
+// NewCallerSilenceHandler 创建按调用者屏蔽日志的处理器
+func NewCallerSilenceHandler(handler Handler, silenced []string) *CallerSilenceHandler {
+	return &CallerSilenceHandler{
+		handler:  handler,
+		Silenced: silenced,
+	}
+}
+
+// Handle 处理日志事件
+func (h *CallerSilenceHandler) Handle(event LogEvent) error {
+	if h.isSilenced(event) {
+		return nil
+	}
+	return h.handler.Handle(event)
+}
+
+// Format 格式化日志事件
+func (h *CallerSilenceHandler) Format(event LogEvent) ([]byte, error) {
+	return h.handler.Format(event)
+}
+
+// ShouldHandle 是否应该处理该事件
+func (h *CallerSilenceHandler) ShouldHandle(event LogEvent) bool {
+	if h.isSilenced(event) {
+		return false
+	}
+	return h.handler.ShouldHandle(event)
+}
+
+// Close 关闭处理器
+func (h *CallerSilenceHandler) Close() error {
+	return h.handler.Close()
+}
+
+// isSilenced 判断事件的调用者是否被屏蔽
+func (h *CallerSilenceHandler) isSilenced(event LogEvent) bool {
+	if event.Caller == "" && event.CallerFile == "" {
+		return false
+	}
+	for _, prefix := range h.Silenced {
+		if prefix == "" {
+			continue
+		}
+		if strings.HasPrefix(event.Caller, prefix) || matchPathPrefix(event.CallerFile, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPrefix 判断 prefix 是否按完整的路径段出现在 file 中
+// 源文件路径通常是绝对路径或模块缓存中的路径（如 github.com/foo/bar@v1.0.0/x.go），
+// 因此 prefix 不要求从路径开头匹配，最后一段之后可以紧跟版本号
+func matchPathPrefix(file, prefix string) bool {
+	prefix = strings.Trim(filepath.ToSlash(prefix), "/")
+	if file == "" || prefix == "" {
+		return false
+	}
+
+	path := "/" + filepath.ToSlash(file)
+	for i := 0; ; {
+		idx := strings.Index(path[i:], "/"+prefix)
+		if idx < 0 {
+			return false
+		}
+		end := i + idx + 1 + len(prefix)
+		if end == len(path) || path[end] == '/' || path[end] == '@' {
+			return true
+		}
+		i = end
+	}
+}